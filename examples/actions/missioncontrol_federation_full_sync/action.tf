@@ -0,0 +1,6 @@
+action "missioncontrol_federation_full_sync" "after_restore" {
+  config {
+    source_id  = "JPD-1"
+    target_ids = ["JPD-2"]
+  }
+}
@@ -0,0 +1 @@
+terraform import missioncontrol_access_federation_target.my-target JPD-1:JPD-2
@@ -0,0 +1,10 @@
+resource "missioncontrol_access_federation_target" "my-target" {
+  source_id  = "JPD-1"
+  target_id  = "JPD-2"
+  target_url = "http://myartifactory-2.jfrog.io/access"
+  entities   = ["USERS", "GROUPS", "PERMISSIONS"]
+
+  permission_filters = {
+    include_patterns = ["some-regex"]
+  }
+}
@@ -0,0 +1 @@
+terraform import missioncontrol_access_federation_topology.my-topology JPD-1,JPD-2
\ No newline at end of file
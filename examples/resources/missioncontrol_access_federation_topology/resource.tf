@@ -0,0 +1,24 @@
+resource "missioncontrol_access_federation_topology" "my-topology" {
+  edges = [
+    {
+      source_id = "JPD-1"
+      target_id = "JPD-2"
+      entities  = ["USERS", "GROUPS", "PERMISSIONS"]
+    },
+    {
+      source_id = "JPD-2"
+      target_id = "JPD-1"
+      entities  = ["USERS", "GROUPS", "PERMISSIONS"]
+    },
+    {
+      source_id  = "JPD-1"
+      target_id  = "JPD-3"
+      target_url = "http://myplatformserver-3:8082/access"
+      entities   = ["USERS", "PERMISSIONS"]
+      permission_filters = {
+        include_patterns = ["foo"]
+        exclude_patterns = ["bar"]
+      }
+    },
+  ]
+}
@@ -0,0 +1,7 @@
+resource "missioncontrol_circle_of_trust" "my-circle-of-trust" {
+  members = ["JPD-1", "https://myplatformserver-2.jfrog.io/access"]
+
+  member_access_tokens = {
+    "https://myplatformserver-2.jfrog.io/access" = var.platform_2_access_token
+  }
+}
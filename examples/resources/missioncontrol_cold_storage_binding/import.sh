@@ -0,0 +1 @@
+terraform import missioncontrol_cold_storage_binding.prod JPD-2
@@ -0,0 +1,4 @@
+resource "missioncontrol_cold_storage_binding" "prod" {
+  jpd_id              = "JPD-1"
+  cold_storage_jpd_id = "JPD-2"
+}
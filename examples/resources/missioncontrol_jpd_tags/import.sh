@@ -0,0 +1 @@
+terraform import missioncontrol_jpd_tags.payments JPD-1:team:payments,team:search
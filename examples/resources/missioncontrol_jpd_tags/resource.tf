@@ -0,0 +1,4 @@
+resource "missioncontrol_jpd_tags" "payments" {
+  jpd_id = "JPD-1"
+  tags   = ["team:payments"]
+}
@@ -0,0 +1,91 @@
+package missioncontrol
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ action.Action = &federationFullSyncAction{}
+var _ action.ActionWithConfigure = &federationFullSyncAction{}
+
+type federationFullSyncAction struct {
+	ProviderData ProviderMetadata
+	TypeName     string
+}
+
+func NewFederationFullSyncAction() action.Action {
+	return &federationFullSyncAction{
+		TypeName: "missioncontrol_federation_full_sync",
+	}
+}
+
+func (a *federationFullSyncAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = a.TypeName
+}
+
+func (a *federationFullSyncAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"source_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "ID of the Access Federation source Platform Deployment.",
+			},
+			"target_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+					),
+				},
+				Description: "IDs of the target Platform Deployments to sync. When omitted, every target of the source is synced.",
+			},
+		},
+		MarkdownDescription: "Full sync of users, groups, permissions, and tokens from an [Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation) source Platform Deployment to its targets, e.g. after restoring a target from backup.\n\n" +
+			"!>Not supported yet: Mission Control has no documented API to trigger a full sync, so invoking the action fails. Trigger the full sync from the Mission Control UI instead.\n\n" +
+			"~>Requires Terraform 1.14 or later.",
+	}
+}
+
+type federationFullSyncActionModel struct {
+	SourceID  types.String `tfsdk:"source_id"`
+	TargetIDs types.Set    `tfsdk:"target_ids"`
+}
+
+func (a *federationFullSyncAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	a.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (a *federationFullSyncAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	resp.Diagnostics.Append(a.ProviderData.checkReadOnly(a.TypeName, "invoke")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config federationFullSyncActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddError(
+		"Access Federation full sync is not supported",
+		fmt.Sprintf("Mission Control has no documented API to trigger a full sync of %s, so %s can't run it. Trigger the full sync from the Mission Control UI instead.", config.SourceID.ValueString(), a.TypeName),
+	)
+}
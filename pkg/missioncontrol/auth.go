@@ -0,0 +1,246 @@
+package missioncontrol
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// reauthResultKey is the request context key of the reauthResult of a
+// request.
+type reauthResultKey struct{}
+
+// reauthResult carries the re-authentication failure of a request from the
+// transport to the response middleware, which reports it.
+type reauthResult struct {
+	err error
+}
+
+// authenticator remembers how the provider obtained its access token during
+// Configure so that an expired token (e.g. a short-lived OIDC exchanged token)
+// can be replaced transparently in the middle of an apply.
+type authenticator struct {
+	url                  string
+	accessToken          string
+	oidcProviderName     string
+	tfcCredentialTagName string
+
+	// newAccessToken obtains the access token replacing a rejected one.
+	// Default to exchangeAccessToken.
+	newAccessToken func(ctx context.Context) (string, error)
+
+	mutex sync.Mutex
+	// token is the current access token
+	token string
+	// rejected are the access tokens replaced by token
+	rejected map[string]bool
+}
+
+// resolveAccessToken returns the access token using the same precedence as the
+// provider configuration: provider `access_token` attribute, then OIDC token
+// exchange, then `JFROG_ACCESS_TOKEN` environment variable.
+func (a *authenticator) resolveAccessToken(ctx context.Context, exchangeClient *resty.Client) (string, error) {
+	accessToken := util.CheckEnvVars([]string{"JFROG_ACCESS_TOKEN"}, "")
+
+	if a.oidcProviderName != "" {
+		oidcAccessToken, err := util.OIDCTokenExchange(ctx, exchangeClient, a.oidcProviderName, a.tfcCredentialTagName)
+		if err != nil {
+			return "", err
+		}
+
+		if oidcAccessToken != "" {
+			accessToken = oidcAccessToken
+		}
+	}
+
+	if a.accessToken != "" {
+		accessToken = a.accessToken
+	}
+
+	return accessToken, nil
+}
+
+// exchangeAccessToken resolves the access token again with a separate client,
+// so the OIDC token exchange itself is not subject to re-authentication.
+func (a *authenticator) exchangeAccessToken(ctx context.Context) (string, error) {
+	exchangeClient, err := client.Build(a.url, productId)
+	if err != nil {
+		return "", err
+	}
+
+	accessToken, err := a.resolveAccessToken(ctx, exchangeClient)
+	if err != nil {
+		return "", fmt.Errorf("failed OIDC ID token exchange: %w", err)
+	}
+
+	return accessToken, nil
+}
+
+// currentToken returns the access token to send instead of token, which
+// differs when token has already been replaced.
+func (a *authenticator) currentToken(token string) string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.rejected[token] {
+		return a.token
+	}
+
+	return token
+}
+
+// reauthenticate returns the access token replacing the rejected one.
+// Concurrent requests rejected with the same token only trigger a single
+// exchange. An empty token is returned for tokens not obtained by the
+// authenticator, e.g. access tokens of circle of trust members.
+func (a *authenticator) reauthenticate(ctx context.Context, rejectedToken string) (string, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.rejected[rejectedToken] {
+		// another request has already replaced the token
+		return a.token, nil
+	}
+
+	if rejectedToken != a.token {
+		return "", nil
+	}
+
+	newAccessToken := a.newAccessToken
+	if newAccessToken == nil {
+		newAccessToken = a.exchangeAccessToken
+	}
+
+	accessToken, err := newAccessToken(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if accessToken == "" || accessToken == rejectedToken {
+		return "", fmt.Errorf("no new access token is available. An access token from the `access_token` attribute or the `JFROG_ACCESS_TOKEN` environment variable can't be renewed while the provider is running. Use `oidc_provider_name` to obtain new access tokens with OIDC token exchange, or an access token which doesn't expire before the end of the run")
+	}
+
+	if a.rejected == nil {
+		a.rejected = map[string]bool{}
+	}
+	a.rejected[rejectedToken] = true
+	a.token = accessToken
+
+	return accessToken, nil
+}
+
+// onBeforeRequest attaches the reauthResult to the request.
+func (a *authenticator) onBeforeRequest(c *resty.Client, request *resty.Request) error {
+	request.SetContext(context.WithValue(request.Context(), reauthResultKey{}, &reauthResult{}))
+	return nil
+}
+
+// onAfterResponse reports why the request was not retried after the platform
+// rejected its access token.
+func (a *authenticator) onAfterResponse(c *resty.Client, response *resty.Response) error {
+	if response.StatusCode() != http.StatusUnauthorized {
+		return nil
+	}
+
+	result, ok := response.Request.Context().Value(reauthResultKey{}).(*reauthResult)
+	if !ok || result.err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("access token was rejected with HTTP 401 (%s) and re-authentication failed: %w", response.String(), result.err)
+}
+
+// install re-authenticates the requests of the client. The client must carry
+// the access token obtained by the authenticator.
+func (a *authenticator) install(c *resty.Client) {
+	a.mutex.Lock()
+	if a.token == "" {
+		a.token = c.Token
+	}
+	a.mutex.Unlock()
+
+	base := c.GetClient().Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	c.SetTransport(&reauthTransport{
+		base:          base,
+		authenticator: a,
+	}).
+		OnBeforeRequest(a.onBeforeRequest).
+		OnAfterResponse(a.onAfterResponse)
+}
+
+// reauthTransport re-authenticates and sends a request once more when the
+// platform responds with 401. Retrying below resty leaves its retry
+// conditions, and so its retry of transport errors, untouched.
+type reauthTransport struct {
+	base          http.RoundTripper
+	authenticator *authenticator
+}
+
+func (t *reauthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return t.base.RoundTrip(req)
+	}
+
+	// requests built before another request re-authenticated still carry
+	// the rejected token
+	if currentToken := t.authenticator.currentToken(token); currentToken != token {
+		req = withAccessToken(req, currentToken)
+		token = currentToken
+	}
+
+	response, err := t.base.RoundTrip(req)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+
+	// the request can only be sent again if its body can be read again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return response, nil
+	}
+
+	accessToken, err := t.authenticator.reauthenticate(req.Context(), token)
+	if err != nil {
+		if result, ok := req.Context().Value(reauthResultKey{}).(*reauthResult); ok {
+			result.err = err
+		}
+		return response, nil
+	}
+
+	if accessToken == "" {
+		return response, nil
+	}
+
+	retry := withAccessToken(req, accessToken)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return response, nil
+		}
+		retry.Body = body
+	}
+
+	_, _ = io.Copy(io.Discard, response.Body)
+	response.Body.Close()
+
+	// the response of the retry is returned as is, even when the new
+	// access token is rejected too
+	return t.base.RoundTrip(retry)
+}
+
+func withAccessToken(req *http.Request, accessToken string) *http.Request {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	return req
+}
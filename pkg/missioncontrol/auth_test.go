@@ -0,0 +1,170 @@
+package missioncontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func newAuthenticatedClient(t *testing.T, url string, newAccessToken func(ctx context.Context) (string, error)) *resty.Client {
+	t.Helper()
+
+	c := resty.New().
+		SetBaseURL(url).
+		SetAuthToken("expired").
+		SetRetryCount(2).
+		SetRetryWaitTime(time.Millisecond).
+		SetRetryMaxWaitTime(time.Millisecond)
+
+	auth := &authenticator{
+		newAccessToken: newAccessToken,
+	}
+	auth.install(c)
+
+	return c
+}
+
+func TestAuthenticator_concurrentReauthentication(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer renewed" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var exchanges atomic.Int32
+	c := newAuthenticatedClient(t, server.URL, func(ctx context.Context) (string, error) {
+		exchanges.Add(1)
+		// let the other requests be rejected in the meantime
+		time.Sleep(10 * time.Millisecond)
+		return "renewed", nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			response, err := c.R().SetBody(map[string]string{"name": "test"}).Post("/")
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+				return
+			}
+			if response.StatusCode() != http.StatusOK {
+				t.Errorf("expected status 200, got %d", response.StatusCode())
+			}
+		}()
+	}
+	wg.Wait()
+
+	if exchanges.Load() != 1 {
+		t.Errorf("expected 1 token exchange, got %d", exchanges.Load())
+	}
+
+	// later requests are sent with the renewed token straight away
+	response, err := c.R().Get("/")
+	if err != nil || response.StatusCode() != http.StatusOK {
+		t.Fatalf("expected status 200, got %v, %v", response, err)
+	}
+	if exchanges.Load() != 1 {
+		t.Errorf("expected 1 token exchange, got %d", exchanges.Load())
+	}
+}
+
+func TestAuthenticator_renewedTokenRejected(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	var exchanges atomic.Int32
+	c := newAuthenticatedClient(t, server.URL, func(ctx context.Context) (string, error) {
+		exchanges.Add(1)
+		return "renewed", nil
+	})
+
+	response, err := c.R().Get("/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if response.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", response.StatusCode())
+	}
+
+	if requests.Load() != 2 {
+		t.Errorf("expected 2 requests, got %d", requests.Load())
+	}
+
+	if exchanges.Load() != 1 {
+		t.Errorf("expected 1 token exchange, got %d", exchanges.Load())
+	}
+}
+
+func TestAuthenticator_noNewToken(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	c := newAuthenticatedClient(t, server.URL, func(ctx context.Context) (string, error) {
+		return "expired", nil
+	})
+
+	_, err := c.R().Get("/")
+	if err == nil || !strings.Contains(err.Error(), "re-authentication failed: no new access token is available") {
+		t.Fatalf("expected re-authentication error, got %v", err)
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("expected 1 request, got %d", requests.Load())
+	}
+}
+
+func TestAuthenticator_transportErrorRetried(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			// drop the connection without response
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newAuthenticatedClient(t, server.URL, func(ctx context.Context) (string, error) {
+		t.Error("unexpected token exchange")
+		return "", nil
+	})
+
+	response, err := c.R().Get("/")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		t.Errorf("expected status 200, got %d", response.StatusCode())
+	}
+
+	if requests.Load() != 3 {
+		t.Errorf("expected 3 requests, got %d", requests.Load())
+	}
+}
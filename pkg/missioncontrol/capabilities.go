@@ -0,0 +1,210 @@
+package missioncontrol
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/samber/lo"
+)
+
+// capability is a platform feature which is only available from a minimum
+// platform version.
+type capability struct {
+	Description string
+	MinVersion  string
+}
+
+var (
+	jpdJoinKeyCapability = capability{
+		Description: "Registering Platform Deployment with join key",
+		MinVersion:  "7.0.0",
+	}
+	accessFederationCapability = capability{
+		Description: "Access Federation REST API",
+		MinVersion:  "7.77.3",
+	}
+)
+
+// accessFederationEntityCapabilities lists the minimum platform version for
+// each entity type which can be synced by Access Federation.
+var accessFederationEntityCapabilities = map[string]capability{
+	"USERS": {
+		Description: "Access Federation of `USERS` entity",
+		MinVersion:  accessFederationCapability.MinVersion,
+	},
+	"GROUPS": {
+		Description: "Access Federation of `GROUPS` entity",
+		MinVersion:  accessFederationCapability.MinVersion,
+	},
+	"PERMISSIONS": {
+		Description: "Access Federation of `PERMISSIONS` entity",
+		MinVersion:  accessFederationCapability.MinVersion,
+	},
+	"TOKENS": {
+		Description: "Access Federation of `TOKENS` entity",
+		MinVersion:  "7.84.3",
+	},
+}
+
+// latestAccessFederationEntityVersion returns the most recent platform version
+// which introduced an entity type in accessFederationEntityCapabilities. Up to
+// this version, the table lists every entity type Access Federation can sync.
+func latestAccessFederationEntityVersion() string {
+	return lo.MaxBy(lo.Values(accessFederationEntityCapabilities), func(a, b capability) bool {
+		newer, _ := util.CheckVersion(a.MinVersion, b.MinVersion)
+		return newer && a.MinVersion != b.MinVersion
+	}).MinVersion
+}
+
+// capabilityRegistry answers whether the configured platform supports a
+// capability. The platform version is only detected when first consulted.
+type capabilityRegistry struct {
+	versions *versionResolver
+}
+
+func newCapabilityRegistry(versions *versionResolver) *capabilityRegistry {
+	return &capabilityRegistry{
+		versions: versions,
+	}
+}
+
+func (r *capabilityRegistry) Supports(c capability) (bool, error) {
+	version, err := r.versions.ArtifactoryVersion()
+	if err != nil {
+		return false, err
+	}
+
+	return util.CheckVersion(version, c.MinVersion)
+}
+
+// Require adds an error diagnostic for attrPath when the platform does not
+// support the capability. When the platform version can't be determined, a
+// warning is added instead and the API is left to decide.
+func (r *capabilityRegistry) Require(c capability, attrPath path.Path) (ds diag.Diagnostics) {
+	if r == nil {
+		// provider is not configured yet, e.g. during validation
+		return
+	}
+
+	supported, err := r.Supports(c)
+	if err != nil {
+		ds.AddAttributeWarning(
+			attrPath,
+			"Unable to verify platform capability",
+			fmt.Sprintf("%s requires version %s or later but the platform version can't be determined: %s", c.Description, c.MinVersion, err),
+		)
+		return
+	}
+
+	if !supported {
+		version, _ := r.versions.ArtifactoryVersion()
+		ds.AddAttributeError(
+			attrPath,
+			"Unsupported platform version",
+			fmt.Sprintf("%s requires version %s or later. Artifactory version is %s.", c.Description, c.MinVersion, version),
+		)
+	}
+
+	return
+}
+
+// RequireAccessFederation checks the Access Federation REST API and each of
+// the entity types to sync are supported by the platform. Diagnostics are
+// added for attrPath, which holds the entity types.
+func (r *capabilityRegistry) RequireAccessFederation(ctx context.Context, entities types.Set, attrPath path.Path) (ds diag.Diagnostics) {
+	if r == nil {
+		// provider is not configured yet, e.g. during validation
+		return
+	}
+
+	ds.Append(r.Require(accessFederationCapability, attrPath)...)
+	if ds.HasError() || entities.IsNull() || entities.IsUnknown() {
+		return
+	}
+
+	// no point checking the version of each entity type when the platform
+	// version can't be determined
+	versionKnown := len(ds) == 0
+
+	var entityTypes []string
+	ds.Append(entities.ElementsAs(ctx, &entityTypes, false)...)
+	if ds.HasError() {
+		return
+	}
+
+	ds.Append(r.requireEntityTypes(entityTypes, attrPath, versionKnown)...)
+	if ds.HasError() || !versionKnown {
+		return
+	}
+
+	for _, entityType := range entityTypes {
+		if c, ok := accessFederationEntityCapabilities[entityType]; ok {
+			ds.Append(r.Require(c, attrPath)...)
+		}
+	}
+
+	return
+}
+
+// requireEntityTypes checks the entity types not listed in
+// accessFederationEntityCapabilities. These are errors on platform versions the
+// table is complete for. On newer platform versions, or when the version can't
+// be determined, they only get a warning and the API is left to decide.
+func (r *capabilityRegistry) requireEntityTypes(entityTypes []string, attrPath path.Path, versionKnown bool) (ds diag.Diagnostics) {
+	unknownEntityTypes := lo.Reject(entityTypes, func(entityType string, _ int) bool {
+		_, ok := accessFederationEntityCapabilities[entityType]
+		return ok
+	})
+	if len(unknownEntityTypes) == 0 {
+		return
+	}
+
+	latestVersion := latestAccessFederationEntityVersion()
+
+	var newer bool
+	var version string
+	var supportedEntityTypes []string
+	if versionKnown {
+		version, _ = r.versions.ArtifactoryVersion()
+		newer, _ = util.CheckVersion(version, latestVersion)
+		newer = newer && version != latestVersion
+
+		for entityType, c := range accessFederationEntityCapabilities {
+			if supported, _ := util.CheckVersion(version, c.MinVersion); supported {
+				supportedEntityTypes = append(supportedEntityTypes, entityType)
+			}
+		}
+		slices.Sort(supportedEntityTypes)
+	}
+
+	for _, entityType := range unknownEntityTypes {
+		switch {
+		case !versionKnown:
+			ds.AddAttributeWarning(
+				attrPath,
+				"Unable to verify entity type",
+				fmt.Sprintf("Entity type %s is not known to this provider, and the platform version can't be determined.", entityType),
+			)
+		case newer:
+			ds.AddAttributeWarning(
+				attrPath,
+				"Unable to verify entity type",
+				fmt.Sprintf("Entity type %s is not known to this provider. It is left to Mission Control to verify, as Artifactory version %s is newer than %s.", entityType, version, latestVersion),
+			)
+		default:
+			ds.AddAttributeError(
+				attrPath,
+				"Unsupported entity type",
+				fmt.Sprintf("Entity type %s can't be synced by Access Federation. Artifactory version is %s, which supports: %s.", entityType, version, strings.Join(supportedEntityTypes, ", ")),
+			)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,115 @@
+package missioncontrol
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLatestAccessFederationEntityVersion(t *testing.T) {
+	if version := latestAccessFederationEntityVersion(); version != "7.84.3" {
+		t.Errorf("expected version 7.84.3, got %s", version)
+	}
+}
+
+func TestCapabilityRegistry_RequireAccessFederation(t *testing.T) {
+	testCases := []struct {
+		name               string
+		artifactoryVersion string
+		entityTypes        []string
+		expectedErrors     int
+		expectedWarnings   int
+	}{
+		{
+			name:               "supported",
+			artifactoryVersion: "7.84.3",
+			entityTypes:        []string{"USERS", "TOKENS"},
+		},
+		{
+			name:               "entity type too recent",
+			artifactoryVersion: "7.80.0",
+			entityTypes:        []string{"USERS", "TOKENS"},
+			expectedErrors:     1,
+		},
+		{
+			name:               "unknown entity type",
+			artifactoryVersion: "7.84.3",
+			entityTypes:        []string{"USERS", "WIDGETS"},
+			expectedErrors:     1,
+		},
+		{
+			name:               "unknown entity type on newer platform",
+			artifactoryVersion: "7.90.14",
+			entityTypes:        []string{"USERS", "WIDGETS"},
+			expectedWarnings:   1,
+		},
+		{
+			name:             "unknown platform version",
+			entityTypes:      []string{"USERS", "WIDGETS"},
+			expectedWarnings: 2,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var artifactoryRequests atomic.Int32
+			server := newVersionServer(t, testCase.artifactoryVersion, &artifactoryRequests)
+
+			registry := newCapabilityRegistry(newVersionResolver(resty.New().SetBaseURL(server.URL)))
+
+			entities, ds := types.SetValueFrom(context.Background(), types.StringType, testCase.entityTypes)
+			if ds.HasError() {
+				t.Fatalf("unexpected error: %v", ds)
+			}
+
+			ds = registry.RequireAccessFederation(context.Background(), entities, path.Root("entities"))
+
+			if ds.ErrorsCount() != testCase.expectedErrors {
+				t.Errorf("expected %d errors, got %v", testCase.expectedErrors, ds.Errors())
+			}
+			if ds.WarningsCount() != testCase.expectedWarnings {
+				t.Errorf("expected %d warnings, got %v", testCase.expectedWarnings, ds.Warnings())
+			}
+		})
+	}
+}
+
+func TestCapabilityRegistry_RequireAccessFederation_notConfigured(t *testing.T) {
+	var registry *capabilityRegistry
+
+	entities := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("WIDGETS")})
+	if ds := registry.RequireAccessFederation(context.Background(), entities, path.Root("entities")); len(ds) != 0 {
+		t.Errorf("expected no diagnostic, got %v", ds)
+	}
+}
+
+func TestIsEntityType(t *testing.T) {
+	testCases := map[string]bool{
+		"USERS":           true,
+		"RELEASE_BUNDLES": true,
+		"users":           false,
+		"":                false,
+		"_USERS":          false,
+		"USERS,GROUPS":    false,
+	}
+
+	for value, valid := range testCases {
+		response := validator.StringResponse{Diagnostics: diag.Diagnostics{}}
+		isEntityType().ValidateString(
+			context.Background(),
+			validator.StringRequest{Path: path.Root("entities"), ConfigValue: types.StringValue(value)},
+			&response,
+		)
+
+		if response.Diagnostics.HasError() == valid {
+			t.Errorf("expected %q to be valid: %t, got %v", value, valid, response.Diagnostics)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package missioncontrol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+type jpdDecommissionResourceModel struct {
+	DetachLicenses        types.Bool `tfsdk:"detach_licenses"`
+	RemoveFromFederations types.Bool `tfsdk:"remove_from_federations"`
+}
+
+// removeJPDFromFederations removes the JPD from the targets of every Access
+// Federation source, and removes the targets of the JPD itself when it is a
+// source. Returns the IDs of the sources which were updated.
+func removeJPDFromFederations(client *resty.Client, jpdID string) ([]string, error) {
+	accessFederations, err := getAccessFederations(client)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Access Federation configurations: %w", err)
+	}
+
+	var updated []string
+	for _, accessFederation := range accessFederations {
+		if accessFederation.Source == jpdID {
+			if len(accessFederation.Targets) == 0 {
+				continue
+			}
+
+			if err := clearAccessFederationTargets(client, jpdID); err != nil {
+				return updated, err
+			}
+
+			updated = append(updated, jpdID)
+			continue
+		}
+
+		isTarget := lo.ContainsBy(
+			accessFederation.Targets,
+			func(target accessFederationTargetGetAllAPIModel) bool {
+				return target.ID == jpdID
+			},
+		)
+		if !isTarget {
+			continue
+		}
+
+		if _, err := deleteAccessFederationLink(client, accessFederation.Source, jpdID); err != nil {
+			return updated, err
+		}
+
+		updated = append(updated, accessFederation.Source)
+	}
+
+	return updated, nil
+}
+
+// clearAccessFederationTargets removes every target of the source JPD, through
+// updateAccessFederation so targets added concurrently are removed as well.
+func clearAccessFederationTargets(client *resty.Client, sourceID string) error {
+	_, err := updateAccessFederation(
+		client,
+		sourceID,
+		func(current *accessFederationGetResponseAPIModel) *accessFederationRequestAPIModel {
+			if len(current.Targets) == 0 {
+				return nil
+			}
+
+			return &accessFederationRequestAPIModel{
+				ID:       sourceID,
+				Entities: current.Entities,
+				Targets:  []accessFederationTargetAPIModel{},
+			}
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to update Access Federation configuration of %s: %w", sourceID, err)
+	}
+
+	return nil
+}
+
+// decommissionJPD runs the enabled decommission steps before the JPD is
+// deleted. Detaching licenses is rejected during plan, see detach_licenses. The result of each step is reported as a warning, as the framework
+// has no informational diagnostics, and the first failed step as an error.
+func decommissionJPD(client *resty.Client, jpdID string, decommission jpdDecommissionResourceModel) (ds diag.Diagnostics) {
+	if decommission.RemoveFromFederations.ValueBool() {
+		sources, err := removeJPDFromFederations(client, jpdID)
+		if err != nil {
+			ds.AddError(
+				"Unable to remove from Access Federations",
+				fmt.Sprintf("Platform Deployment %s is not deleted. Access Federation sources updated so far: [%s]. Error: %s", jpdID, strings.Join(sources, ", "), err),
+			)
+			return
+		}
+
+		ds.AddWarning(
+			"Decommission: removed from Access Federations",
+			fmt.Sprintf("Platform Deployment %s is removed from the Access Federation configuration of %d source(s): [%s].", jpdID, len(sources), strings.Join(sources, ", ")),
+		)
+	}
+
+	return
+}
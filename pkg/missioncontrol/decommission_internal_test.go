@@ -0,0 +1,57 @@
+package missioncontrol
+
+import (
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestClearAccessFederationTargets_concurrentChange(t *testing.T) {
+	var puts []accessFederationRequestAPIModel
+	server := newAccessFederationServer(
+		t,
+		func(get int32) []accessFederationTargetAPIModel {
+			// another Terraform run adds JPD-3 between the first read and
+			// the write
+			if get == 2 {
+				return []accessFederationTargetAPIModel{
+					{ID: "JPD-2", URL: "https://jpd-2/access", Entities: []string{"USERS"}},
+					{ID: "JPD-3", URL: "https://jpd-3/access", Entities: []string{"USERS"}},
+				}
+			}
+			return nil
+		},
+		&puts,
+	)
+
+	if err := clearAccessFederationTargets(resty.New().SetBaseURL(server.URL), "JPD-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(puts) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(puts))
+	}
+
+	if len(puts[0].Targets) != 0 || len(puts[0].Entities) != 1 {
+		t.Errorf("expected no target and the source entities kept, got %v", puts[0])
+	}
+}
+
+func TestClearAccessFederationTargets_noTarget(t *testing.T) {
+	var puts []accessFederationRequestAPIModel
+	server := newAccessFederationServer(
+		t,
+		func(get int32) []accessFederationTargetAPIModel {
+			return []accessFederationTargetAPIModel{}
+		},
+		&puts,
+	)
+
+	if err := clearAccessFederationTargets(resty.New().SetBaseURL(server.URL), "JPD-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(puts) != 0 {
+		t.Errorf("expected no update, got %d", len(puts))
+	}
+}
@@ -0,0 +1,66 @@
+package missioncontrol
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var deletionProtectionSchemaAttribute = schema.BoolAttribute{
+	Optional:            true,
+	Computed:            true,
+	Default:             booldefault.StaticBool(true),
+	MarkdownDescription: "When set to `true`, destroying or replacing the resource fails during plan. Must be set to `false`, and applied, before the resource can be destroyed or replaced. Default to `true`.",
+}
+
+// deletionProtected returns true when deletion protection is enabled. State
+// written before the attribute existed, or imported, has no value and is not
+// protected until the default is applied, so upgrading the provider doesn't
+// block destroys planned with existing configurations.
+func deletionProtected(deletionProtection types.Bool) bool {
+	return deletionProtection.ValueBool()
+}
+
+// checkDeletionProtection returns an error diagnostic when the resource is
+// planned to be destroyed, or replaced, while deletion protection is enabled in
+// the prior state. Setting `deletion_protection` to false in the same apply has
+// no effect.
+func checkDeletionProtection(ctx context.Context, typeName string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) (ds diag.Diagnostics) {
+	// nothing to protect when the resource is being created
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var operation string
+	switch {
+	case req.Plan.Raw.IsNull():
+		operation = "destroy"
+	// only attributes with a RequiresReplace plan modifier, e.g. of
+	// missioncontrol_license_bucket, cause a replacement. Changes of
+	// missioncontrol_jpd are all updated in place.
+	case len(resp.RequiresReplace) > 0:
+		operation = "replace"
+	default:
+		return
+	}
+
+	var deletionProtection types.Bool
+	ds.Append(req.State.GetAttribute(ctx, path.Root("deletion_protection"), &deletionProtection)...)
+	if ds.HasError() || !deletionProtected(deletionProtection) {
+		return
+	}
+
+	ds.AddAttributeError(
+		path.Root("deletion_protection"),
+		"Deletion protection is enabled",
+		fmt.Sprintf("Unable to %s %s as `deletion_protection` is enabled. Set `deletion_protection` to `false` and apply, before the resource can be destroyed or replaced.", operation, typeName),
+	)
+
+	return
+}
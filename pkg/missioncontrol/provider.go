@@ -3,9 +3,14 @@ package missioncontrol
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -14,6 +19,7 @@ import (
 	"github.com/jfrog/terraform-provider-shared/client"
 	"github.com/jfrog/terraform-provider-shared/util"
 	validator_string "github.com/jfrog/terraform-provider-shared/validator/fw/string"
+	"github.com/samber/lo"
 )
 
 var Version = "1.0.0"
@@ -21,10 +27,40 @@ var Version = "1.0.0"
 // needs to be exported so make file can update this
 var productId = "terraform-provider-mission-control/" + Version
 
+const readOnlyEnvVar = "MISSIONCONTROL_READ_ONLY"
+
 var _ provider.Provider = (*MissionControlProvider)(nil)
+var _ provider.ProviderWithActions = (*MissionControlProvider)(nil)
 
 type MissionControlProvider struct {
-	Meta util.ProviderMetadata
+	Meta ProviderMetadata
+}
+
+// ProviderMetadata is the provider data passed to resources. The embedded
+// ArtifactoryVersion is not populated during Configure; the platform version
+// is only detected when a resource consults capabilities.
+type ProviderMetadata struct {
+	util.ProviderMetadata
+	capabilities *capabilityRegistry
+	// authenticator re-authenticates clients using the provider access token
+	authenticator *authenticator
+	defaultTags   []string
+	// defaultTagsUnknown is set when default_tags is not known until apply
+	defaultTagsUnknown bool
+	readOnly           bool
+}
+
+// checkReadOnly returns an error diagnostic when the provider is configured as
+// read only. Resources must call it before any mutating API call.
+func (m ProviderMetadata) checkReadOnly(typeName, operation string) (ds diag.Diagnostics) {
+	if m.readOnly {
+		ds.AddError(
+			"Provider is read only",
+			fmt.Sprintf("Unable to %s %s as the provider is configured with `read_only` (or `%s` environment variable). Only read and import are allowed.", operation, typeName, readOnlyEnvVar),
+		)
+	}
+
+	return
 }
 
 type missionControlProviderModel struct {
@@ -32,6 +68,8 @@ type missionControlProviderModel struct {
 	AccessToken          types.String `tfsdk:"access_token"`
 	OIDCProviderName     types.String `tfsdk:"oidc_provider_name"`
 	TFCCredentialTagName types.String `tfsdk:"tfc_credential_tag_name"`
+	DefaultTags          types.Set    `tfsdk:"default_tags"`
+	ReadOnly             types.Bool   `tfsdk:"read_only"`
 }
 
 func NewProvider() func() provider.Provider {
@@ -43,7 +81,6 @@ func NewProvider() func() provider.Provider {
 func (p *MissionControlProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	// Check environment variables, first available OS variable will be assigned to the var
 	url := util.CheckEnvVars([]string{"JFROG_URL"}, "")
-	accessToken := util.CheckEnvVars([]string{"JFROG_ACCESS_TOKEN"}, "")
 
 	var config missionControlProviderModel
 
@@ -74,28 +111,22 @@ func (p *MissionControlProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
-	oidcProviderName := config.OIDCProviderName.ValueString()
-	if oidcProviderName != "" {
-		oidcAccessToken, err := util.OIDCTokenExchange(ctx, platformClient, oidcProviderName, config.TFCCredentialTagName.ValueString())
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Failed OIDC ID token exchange",
-				err.Error(),
-			)
-			return
-		}
-
-		// use token from OIDC provider, which should take precedence over
-		// environment variable data, if found.
-		if oidcAccessToken != "" {
-			accessToken = oidcAccessToken
-		}
+	auth := &authenticator{
+		url:                  url,
+		accessToken:          config.AccessToken.ValueString(),
+		oidcProviderName:     config.OIDCProviderName.ValueString(),
+		tfcCredentialTagName: config.TFCCredentialTagName.ValueString(),
 	}
 
-	// use token from configuration, which should take precedence over
-	// environment variable data or OIDC provider, if found.
-	if config.AccessToken.ValueString() != "" {
-		accessToken = config.AccessToken.ValueString()
+	// token from configuration takes precedence over OIDC provider, which
+	// takes precedence over environment variable data.
+	accessToken, err := auth.resolveAccessToken(ctx, platformClient)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed OIDC ID token exchange",
+			err.Error(),
+		)
+		return
 	}
 
 	if accessToken == "" {
@@ -114,27 +145,65 @@ func (p *MissionControlProvider) Configure(ctx context.Context, req provider.Con
 		return
 	}
 
-	version, err := util.GetArtifactoryVersion(platformClient)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error getting Artifactory version",
-			err.Error(),
-		)
+	// re-run the OIDC token exchange or reload the access token when it
+	// expires during a long apply
+	auth.install(platformClient)
+
+	// default tags may depend on values only known after apply. Defer when
+	// Terraform allows it, otherwise tags_all of every jpd is unknown until
+	// apply.
+	defaultTagsUnknown := config.DefaultTags.IsUnknown() || lo.SomeBy(config.DefaultTags.Elements(), attr.Value.IsUnknown)
+	if defaultTagsUnknown && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &provider.Deferred{
+			Reason: provider.DeferredReasonProviderConfigUnknown,
+		}
 		return
 	}
 
+	var defaultTags []string
+	if !defaultTagsUnknown {
+		resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	readOnly := false
+	if v := util.CheckEnvVars([]string{readOnlyEnvVar}, ""); v != "" {
+		readOnly, err = strconv.ParseBool(v)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid read only configuration",
+				fmt.Sprintf("%s environment variable must be a boolean: %s", readOnlyEnvVar, err),
+			)
+			return
+		}
+	}
+
+	// value from configuration takes precedence over environment variable
+	if !config.ReadOnly.IsNull() {
+		readOnly = config.ReadOnly.ValueBool()
+	}
+
 	featureUsage := fmt.Sprintf("Terraform/%s", req.TerraformVersion)
 	go util.SendUsage(ctx, platformClient.R(), productId, featureUsage)
 
-	meta := util.ProviderMetadata{
-		Client:             platformClient,
-		ArtifactoryVersion: version,
+	meta := ProviderMetadata{
+		ProviderMetadata: util.ProviderMetadata{
+			Client: platformClient,
+		},
+		capabilities:       newCapabilityRegistry(newVersionResolver(platformClient)),
+		authenticator:      auth,
+		defaultTags:        defaultTags,
+		defaultTagsUnknown: defaultTagsUnknown,
+		readOnly:           readOnly,
 	}
 
 	p.Meta = meta
 
 	resp.DataSourceData = meta
 	resp.ResourceData = meta
+	resp.ActionData = meta
 }
 
 func (p *MissionControlProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -148,12 +217,23 @@ func (p *MissionControlProvider) DataSources(ctx context.Context) []func() datas
 	}
 }
 
+func (p *MissionControlProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewFederationFullSyncAction,
+	}
+}
+
 func (p *MissionControlProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewLicenseBucketResource,
 		NewJPDResource,
+		NewJPDTagsResource,
+		NewColdStorageBindingResource,
 		NewAccessFederationStarResource,
 		NewAccessFederationMeshResource,
+		NewAccessFederationTargetResource,
+		NewAccessFederationTopologyResource,
+		NewCircleOfTrustResource,
 	}
 }
 
@@ -189,6 +269,20 @@ func (p *MissionControlProvider) Schema(ctx context.Context, req provider.Schema
 				},
 				Description: "Terraform Cloud Workload Identity Token tag name. Use for generating multiple TFC workload identity tokens. When set, the provider will attempt to use env var with this tag name as suffix. **Note:** this is case sensitive, so if set to `JFROG`, then env var `TFC_WORKLOAD_IDENTITY_TOKEN_JFROG` is used instead of `TFC_WORKLOAD_IDENTITY_TOKEN`. See [Generating Multiple Tokens](https://developer.hashicorp.com/terraform/cloud-docs/workspaces/dynamic-provider-credentials/manual-generation#generating-multiple-tokens) on HCP Terraform for more details.",
 			},
+			"default_tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+					),
+				},
+				MarkdownDescription: "Tags to be applied to every `missioncontrol_jpd` resource, in addition to the resource's own `tags`. The merged tags are available in the resource's `tags_all` attribute.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "When set to `true`, any create, update, or delete of resources fails before calling the API. Reading, importing, and data sources are not affected. Useful for drift detection with credentials which must never change Mission Control. This can also be sourced from the `MISSIONCONTROL_READ_ONLY` environment variable. Default to `false`.",
+			},
 		},
 		MarkdownDescription: "The JFrog Mission Control provider provides resources to interact with Mission Control supported by JFrog Platform. See [official documentation](https://jfrog.com/help/r/get-started-with-the-jfrog-platform/jfrog-mission-control) for more details.",
 	}
@@ -3,14 +3,18 @@ package missioncontrol
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -25,9 +29,10 @@ const (
 )
 
 var _ resource.Resource = &accessFederationMeshResource{}
+var _ resource.ResourceWithModifyPlan = &accessFederationMeshResource{}
 
 type accessFederationMeshResource struct {
-	ProviderData util.ProviderMetadata
+	ProviderData ProviderMetadata
 	TypeName     string
 }
 
@@ -49,24 +54,78 @@ func (r *accessFederationMeshResource) Schema(ctx context.Context, req resource.
 			},
 			"ids": schema.SetAttribute{
 				ElementType: types.StringType,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(2),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+					),
+					setvalidator.ExactlyOneOf(path.MatchRoot("names")),
+				},
+				Description: "IDs for the source Platform Deployment. Use [Get Access Federation Candidate API](https://jfrog.com/help/r/jfrog-rest-apis/get-access-federation-candidates) to get a list of ID. Must have at least 2 items. Adding or removing an ID only creates or deletes the links involving that Platform Deployment, while changing `entities` recreates every link of the mesh. Either `ids` or `names` must be set. When `names` is set, these are the resolved IDs.",
+			},
+			"names": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
 				Validators: []validator.Set{
 					setvalidator.SizeAtLeast(2),
 					setvalidator.ValueStringsAre(
 						stringvalidator.LengthAtLeast(1),
 					),
 				},
-				Description: "IDs for the source Platform Deployment. Use [Get Access Federation Candidate API](https://jfrog.com/help/r/jfrog-rest-apis/get-access-federation-candidates) to get a list of ID. Must have at least 2 items.",
+				Description: "Names of the Platform Deployments, resolved to `ids` with Mission Control during plan. Unlike IDs, names can be the same across Mission Control instances, e.g. staging and production. Must have at least 2 items.",
 			},
 			"entities": schema.SetAttribute{
 				ElementType: types.StringType,
 				Required:    true,
 				Validators: []validator.Set{
 					setvalidator.ValueStringsAre(
-						stringvalidator.OneOf("USERS", "GROUPS", "PERMISSIONS", "TOKENS"),
+						isEntityType(),
 					),
 				},
-				Description: "Entity types to sync. Allow values: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`",
+				Description: "Entity types to sync. For example: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`. The entity types are validated against the platform version during plan.",
+			},
+			"allow_partial_failure":  allowPartialFailureSchemaAttribute,
+			"last_operation_results": operationResultsSchemaAttribute,
+			"adopt_existing": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When set to `true`, existing federation relationships involving the members, which are not part of this mesh, are taken over, e.g. a member already being the source of a star. Otherwise these fail the plan, as creating the mesh may rewire them. Default to `false`.",
+			},
+			"missing_links": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"target_id": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+				Computed:            true,
+				MarkdownDescription: "Links between members which don't exist in Mission Control, e.g. removed outside of Terraform. A repair is planned when any link is missing.",
+			},
+			"entity_mismatches": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"target_id": schema.StringAttribute{
+							Computed: true,
+						},
+						"entities": schema.SetAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Entity types actually synced by the link.",
+						},
+					},
+				},
+				Computed:            true,
+				MarkdownDescription: "Links between members which don't sync the same `entities`. A repair is planned when any link mismatches.",
 			},
 		},
 		MarkdownDescription: "Provides a [JFrog Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation) resource to setup Mesh Topology.\n\n" +
@@ -76,45 +135,209 @@ func (r *accessFederationMeshResource) Schema(ctx context.Context, req resource.
 }
 
 type accessFederationMeshResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	IDs      types.Set    `tfsdk:"ids"`
-	Entities types.Set    `tfsdk:"entities"`
+	ID                   types.String `tfsdk:"id"`
+	IDs                  types.Set    `tfsdk:"ids"`
+	Names                types.Set    `tfsdk:"names"`
+	Entities             types.Set    `tfsdk:"entities"`
+	AllowPartialFailure  types.Bool   `tfsdk:"allow_partial_failure"`
+	AdoptExisting        types.Bool   `tfsdk:"adopt_existing"`
+	LastOperationResults types.List   `tfsdk:"last_operation_results"`
+	MissingLinks         types.List   `tfsdk:"missing_links"`
+	EntityMismatches     types.List   `tfsdk:"entity_mismatches"`
 }
 
-func (r *accessFederationMeshResourceModel) fromAPIModel(ctx context.Context, apiModel *accessFederationGetAllResponseAPIModel) (ds diag.Diagnostics) {
-	var ids []string
+var meshLinkAttributeTypes = map[string]attr.Type{
+	"source_id": types.StringType,
+	"target_id": types.StringType,
+}
 
-	ids = append(ids, apiModel.Source)
+var meshLinkElementType = types.ObjectType{
+	AttrTypes: meshLinkAttributeTypes,
+}
 
-	targetIDs := lo.Map(
-		apiModel.Targets,
-		func(target accessFederationTargetGetAllAPIModel, _ int) string {
-			return target.ID
-		},
-	)
-	ids = append(ids, targetIDs...)
+var meshEntityMismatchAttributeTypes = map[string]attr.Type{
+	"source_id": types.StringType,
+	"target_id": types.StringType,
+	"entities":  types.SetType{ElemType: types.StringType},
+}
 
-	idsSet, d := types.SetValueFrom(ctx, types.StringType, ids)
-	if d.HasError() {
-		ds.Append(d...)
+var meshEntityMismatchElementType = types.ObjectType{
+	AttrTypes: meshEntityMismatchAttributeTypes,
+}
+
+// meshLink is a directed source to target link between two mesh members.
+type meshLink struct {
+	SourceID string
+	TargetID string
+	Entities []string
+}
+
+// meshConnectivity is the state of every directed link between mesh members.
+type meshConnectivity struct {
+	Links            []meshLink
+	MissingLinks     []meshLink
+	EntityMismatches []meshLink
+}
+
+// evaluateMeshConnectivity checks every member federates to every other
+// member. When entities is empty, entity mismatches are not evaluated.
+func evaluateMeshConnectivity(jpdIDs, entities []string, accessFederations []accessFederationGetAllResponseAPIModel) meshConnectivity {
+	targetsBySource := map[string]map[string][]string{}
+	for _, accessFederation := range accessFederations {
+		targets, ok := targetsBySource[accessFederation.Source]
+		if !ok {
+			targets = map[string][]string{}
+			targetsBySource[accessFederation.Source] = targets
+		}
+
+		for _, target := range accessFederation.Targets {
+			// target without its own entities syncs the source entities
+			targetEntities := target.Entities
+			if len(targetEntities) == 0 {
+				targetEntities = accessFederation.Entities
+			}
+			targets[target.ID] = targetEntities
+		}
+	}
+
+	members := slices.Clone(jpdIDs)
+	slices.Sort(members)
+
+	var connectivity meshConnectivity
+	for _, sourceID := range members {
+		for _, targetID := range members {
+			if sourceID == targetID {
+				continue
+			}
+
+			targetEntities, ok := targetsBySource[sourceID][targetID]
+			if !ok {
+				connectivity.MissingLinks = append(connectivity.MissingLinks, meshLink{SourceID: sourceID, TargetID: targetID})
+				continue
+			}
+
+			link := meshLink{SourceID: sourceID, TargetID: targetID, Entities: targetEntities}
+			connectivity.Links = append(connectivity.Links, link)
+
+			if len(entities) > 0 && (!lo.Every(entities, targetEntities) || !lo.Every(targetEntities, entities)) {
+				connectivity.EntityMismatches = append(connectivity.EntityMismatches, link)
+			}
+		}
+	}
+
+	return connectivity
+}
+
+// resolveNames sets the IDs of the members configured by name.
+func (r *accessFederationMeshResourceModel) resolveNames(ctx context.Context, client *resty.Client) (ds diag.Diagnostics) {
+	if !r.IDs.IsUnknown() || r.Names.IsNull() || r.Names.IsUnknown() {
+		return
+	}
+
+	var names []string
+	ds.Append(r.Names.ElementsAs(ctx, &names, false)...)
+	if ds.HasError() {
+		return
+	}
+
+	ids, err := resolveJPDNames(client, names)
+	if err != nil {
+		ds.AddAttributeError(path.Root("names"), "Unable to resolve Platform Deployment names", err.Error())
+		return
 	}
+
+	idsSet, d := types.SetValueFrom(ctx, types.StringType, lo.Values(ids))
+	ds.Append(d...)
 	r.IDs = idsSet
 
-	r.ID = types.StringValue(strings.Join(ids, ":"))
+	return
+}
+
+// linksToRepair returns the links found missing or mismatched when refreshing.
+func (r accessFederationMeshResourceModel) linksToRepair() []meshLink {
+	toMeshLink := func(elem attr.Value, _ int) meshLink {
+		attrs := elem.(types.Object).Attributes()
+		return meshLink{
+			SourceID: attrs["source_id"].(types.String).ValueString(),
+			TargetID: attrs["target_id"].(types.String).ValueString(),
+		}
+	}
+
+	return append(
+		lo.Map(r.MissingLinks.Elements(), toMeshLink),
+		lo.Map(r.EntityMismatches.Elements(), toMeshLink)...,
+	)
+}
+
+func (r *accessFederationMeshResourceModel) fromAPIModel(ctx context.Context, connectivity meshConnectivity) (ds diag.Diagnostics) {
+	// imported resource has no entities in state yet
+	if r.Entities.IsNull() || r.Entities.IsUnknown() {
+		entitiesNested := lo.Map(
+			connectivity.Links,
+			func(link meshLink, _ int) []string {
+				return link.Entities
+			},
+		)
+		entities := lo.Uniq(lo.Flatten(entitiesNested))
+
+		entitiesSet, d := types.SetValueFrom(ctx, types.StringType, entities)
+		if d.HasError() {
+			ds.Append(d...)
+		}
+		r.Entities = entitiesSet
+	}
 
-	entitiesNested := lo.Map(
-		apiModel.Targets,
-		func(target accessFederationTargetGetAllAPIModel, _ int) []string {
-			return target.Entities
+	missingLinks := lo.Map(
+		connectivity.MissingLinks,
+		func(link meshLink, _ int) attr.Value {
+			l, d := types.ObjectValue(
+				meshLinkAttributeTypes,
+				map[string]attr.Value{
+					"source_id": types.StringValue(link.SourceID),
+					"target_id": types.StringValue(link.TargetID),
+				},
+			)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			return l
 		},
 	)
-	entities := lo.Uniq(lo.Flatten(entitiesNested))
+	missingLinksList, d := types.ListValue(meshLinkElementType, missingLinks)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+	r.MissingLinks = missingLinksList
+
+	entityMismatches := lo.Map(
+		connectivity.EntityMismatches,
+		func(link meshLink, _ int) attr.Value {
+			entities, d := types.SetValueFrom(ctx, types.StringType, link.Entities)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			m, d := types.ObjectValue(
+				meshEntityMismatchAttributeTypes,
+				map[string]attr.Value{
+					"source_id": types.StringValue(link.SourceID),
+					"target_id": types.StringValue(link.TargetID),
+					"entities":  entities,
+				},
+			)
+			if d.HasError() {
+				ds.Append(d...)
+			}
 
-	entitiesSet, d := types.SetValueFrom(ctx, types.StringType, entities)
+			return m
+		},
+	)
+	entityMismatchesList, d := types.ListValue(meshEntityMismatchElementType, entityMismatches)
 	if d.HasError() {
 		ds.Append(d...)
 	}
-	r.Entities = entitiesSet
+	r.EntityMismatches = entityMismatchesList
 
 	return
 }
@@ -142,13 +365,33 @@ type accessFederationMeshRequestAPIModel struct {
 }
 
 type accessFederationGetAllResponseAPIModel struct {
-	Source  string                                 `json:"source"`
-	Targets []accessFederationTargetGetAllAPIModel `json:"targets"`
+	Source   string                                 `json:"source"`
+	Entities []string                               `json:"entities"`
+	Targets  []accessFederationTargetGetAllAPIModel `json:"targets"`
 }
 
 type accessFederationTargetGetAllAPIModel struct {
 	accessFederationTargetAPIModel
-	Entities []string `json:"entities"`
+}
+
+// getAccessFederations fetches the federation configuration of every
+// configured source JPD.
+func getAccessFederations(client *resty.Client) ([]accessFederationGetAllResponseAPIModel, error) {
+	var accessFederations []accessFederationGetAllResponseAPIModel
+	response, err := client.R().
+		SetQueryParam("includeNonConfiguredJPDs", "false").
+		SetResult(&accessFederations).
+		Get(accessFederationsEndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.IsError() {
+		return nil, fmt.Errorf("%s", response.String())
+	}
+
+	return accessFederations, nil
 }
 
 func (r *accessFederationMeshResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -156,15 +399,143 @@ func (r *accessFederationMeshResource) Configure(ctx context.Context, req resour
 	if req.ProviderData == nil {
 		return
 	}
-	r.ProviderData = req.ProviderData.(util.ProviderMetadata)
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *accessFederationMeshResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan accessFederationMeshResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.ProviderData.capabilities.RequireAccessFederation(ctx, plan.Entities, path.Root("entities"))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// show the IDs resolved from names in the plan
+	if r.ProviderData.Client != nil && plan.IDs.IsUnknown() {
+		resp.Diagnostics.Append(plan.resolveNames(ctx, r.ProviderData.Client)...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("ids"), plan.IDs)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// every link is expected to exist and match after apply. When links are
+	// missing or mismatched in state, this plans an update which repairs them.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("missing_links"), types.ListValueMust(meshLinkElementType, []attr.Value{}))...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("entity_mismatches"), types.ListValueMust(meshEntityMismatchElementType, []attr.Value{}))...)
+
+	// only check when the mesh is to be created or changed
+	if !req.State.Raw.IsNull() && resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	if plan.IDs.IsUnknown() || plan.Entities.IsUnknown() {
+		return
+	}
+
+	var state accessFederationMeshResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	resp.Diagnostics.Append(r.checkExistingFederations(ctx, plan, state)...)
+}
+
+// checkExistingFederations reports existing links involving the members which
+// the apply would take over, but which are not between members of the mesh.
+// Creating the mesh, or changing its entities, affects every member, while
+// adding members only affects the new ones.
+func (r *accessFederationMeshResource) checkExistingFederations(ctx context.Context, plan, state accessFederationMeshResourceModel) (ds diag.Diagnostics) {
+	if r.ProviderData.Client == nil {
+		return
+	}
+
+	var planIDs, stateIDs []string
+	ds.Append(plan.IDs.ElementsAs(ctx, &planIDs, false)...)
+	if !state.IDs.IsNull() && !state.IDs.IsUnknown() {
+		ds.Append(state.IDs.ElementsAs(ctx, &stateIDs, false)...)
+	}
+	if ds.HasError() {
+		return
+	}
+
+	affectedIDs := planIDs
+	if len(stateIDs) > 0 && plan.Entities.Equal(state.Entities) {
+		affectedIDs, _ = lo.Difference(planIDs, stateIDs)
+	}
+	if len(affectedIDs) == 0 {
+		return
+	}
+
+	accessFederations, err := getAccessFederations(r.ProviderData.Client)
+	if err != nil {
+		ds.AddAttributeWarning(
+			path.Root("ids"),
+			"Unable to check existing Access Federations",
+			fmt.Sprintf("Existing federation relationships of the members can't be checked before apply: %s", err),
+		)
+		return
+	}
+
+	var conflicts []string
+	for _, accessFederation := range accessFederations {
+		for _, target := range accessFederation.Targets {
+			if !lo.Contains(affectedIDs, accessFederation.Source) && !lo.Contains(affectedIDs, target.ID) {
+				continue
+			}
+
+			// links between planned members are part of the mesh, e.g. left
+			// over when the state was lost
+			if lo.Contains(planIDs, accessFederation.Source) && lo.Contains(planIDs, target.ID) {
+				continue
+			}
+
+			conflicts = append(conflicts, fmt.Sprintf("%s -> %s", accessFederation.Source, target.ID))
+		}
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+	slices.Sort(conflicts)
+
+	detail := fmt.Sprintf("%d existing link(s) involving the members are not part of this mesh:\n%s", len(conflicts), strings.Join(conflicts, "\n"))
+	if plan.AdoptExisting.ValueBool() {
+		ds.AddAttributeWarning(path.Root("ids"), "Existing Access Federations are adopted", detail)
+		return
+	}
+
+	ds.AddAttributeError(
+		path.Root("ids"),
+		"Members already federate outside of this mesh",
+		detail+"\n\nSet `adopt_existing` to `true` to take them over, or remove them first.",
+	)
+
+	return
 }
 
 func (r *accessFederationMeshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan accessFederationMeshResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(plan.resolveNames(ctx, r.ProviderData.Client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -198,14 +569,23 @@ func (r *accessFederationMeshResource) Create(ctx context.Context, req resource.
 		})
 	}
 
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+
 	var ids []string
 	resp.Diagnostics.Append(plan.IDs.ElementsAs(ctx, &ids, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	plan.ID = types.StringValue(strings.Join(ids, ":"))
+	plan.MissingLinks = types.ListValueMust(meshLinkElementType, []attr.Value{})
+	plan.EntityMismatches = types.ListValueMust(meshEntityMismatchElementType, []attr.Value{})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed links only after saving the state, as the mesh may have
+	// been partially created
+	resp.Diagnostics.Append(resultsDiags...)
 }
 
 func (r *accessFederationMeshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -218,43 +598,30 @@ func (r *accessFederationMeshResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	var accessFederations []accessFederationGetAllResponseAPIModel
-	response, err := r.ProviderData.Client.R().
-		SetQueryParam("includeNonConfiguredJPDs", "false").
-		SetResult(&accessFederations).
-		Get(accessFederationsEndpoint)
-
+	accessFederations, err := getAccessFederations(r.ProviderData.Client)
 	if err != nil {
 		utilfw.UnableToRefreshResourceError(resp, err.Error())
 		return
 	}
 
-	if response.IsError() {
-		utilfw.UnableToRefreshResourceError(resp, response.String())
-		return
-	}
-
 	var jpdIDs []string
 	resp.Diagnostics.Append(state.IDs.ElementsAs(ctx, &jpdIDs, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	sourceAccessFederation, found := lo.Find(
-		accessFederations,
-		func(accessFederation accessFederationGetAllResponseAPIModel) bool {
-			targetIDs := lo.Map(
-				accessFederation.Targets,
-				func(target accessFederationTargetGetAllAPIModel, _ int) string {
-					return target.ID
-				},
-			)
+	var entities []string
+	if !state.Entities.IsNull() {
+		resp.Diagnostics.Append(state.Entities.ElementsAs(ctx, &entities, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-			return lo.Contains(jpdIDs, accessFederation.Source) && lo.Every(jpdIDs, targetIDs)
-		},
-	)
+	connectivity := evaluateMeshConnectivity(jpdIDs, entities, accessFederations)
 
-	if !found {
+	// imported resource must have at least one link to start from
+	if state.Entities.IsNull() && len(connectivity.Links) == 0 {
 		utilfw.UnableToRefreshResourceError(
 			resp,
 			fmt.Sprintf("unabled to find Access Federation Configurations for JPDs: %s", strings.Join(jpdIDs, ", ")),
@@ -262,46 +629,105 @@ func (r *accessFederationMeshResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	// imported resource has no allow partial failure in state yet
+	if state.AllowPartialFailure.IsNull() {
+		state.AllowPartialFailure = types.BoolValue(false)
+	}
+
+	if state.AdoptExisting.IsNull() {
+		state.AdoptExisting = types.BoolValue(false)
+	}
+
 	// Convert from the API data model to the Terraform data model
 	// and refresh any attribute values.
-	resp.Diagnostics.Append(state.fromAPIModel(ctx, &sourceAccessFederation)...)
+	resp.Diagnostics.Append(state.fromAPIModel(ctx, connectivity)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	if len(connectivity.MissingLinks) > 0 || len(connectivity.EntityMismatches) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Access Federation mesh is not fully connected",
+			fmt.Sprintf("%d link(s) are missing and %d link(s) sync different entities. A repair will be planned.", len(connectivity.MissingLinks), len(connectivity.EntityMismatches)),
+		)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *accessFederationMeshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan accessFederationMeshResourceModel
+	var state accessFederationMeshResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(plan.resolveNames(ctx, r.ProviderData.Client)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var accessFederation accessFederationMeshRequestAPIModel
-	resp.Diagnostics.Append(plan.toAPIModel(ctx, &accessFederation)...)
+	var newIDs []string
+	resp.Diagnostics.Append(plan.IDs.ElementsAs(ctx, &newIDs, false)...)
+
+	var oldIDs []string
+	resp.Diagnostics.Append(state.IDs.ElementsAs(ctx, &oldIDs, false)...)
+
+	var entities []string
+	resp.Diagnostics.Append(plan.Entities.ElementsAs(ctx, &entities, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var results []accessFederationResponseAPIModel
-	response, err := r.ProviderData.Client.R().
-		SetBody(accessFederation).
-		SetResult(&results).
-		Post(accessFederationMeshEndpoint)
+	linksToCreate, linksToDelete := meshMembershipChanges(oldIDs, newIDs, state.linksToRepair())
 
-	if err != nil {
-		utilfw.UnableToUpdateResourceError(resp, err.Error())
+	// links involving members removed from the mesh are torn down whether
+	// the entities change or not
+	results, d := r.deleteMeshLinks(ctx, linksToDelete)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if response.IsError() {
-		utilfw.UnableToUpdateResourceError(resp, response.String())
-		return
+	if plan.Entities.Equal(state.Entities) {
+		// same entities, only links involving new members, and links found
+		// broken when refreshing, need to be created
+		linkResults, d := r.createMeshLinks(ctx, linksToCreate, entities)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		results = append(results, linkResults...)
+	} else {
+		// every link syncs different entities, so the whole mesh is recreated
+		var accessFederation accessFederationMeshRequestAPIModel
+		resp.Diagnostics.Append(plan.toAPIModel(ctx, &accessFederation)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var meshResults []accessFederationResponseAPIModel
+		response, err := r.ProviderData.Client.R().
+			SetBody(accessFederation).
+			SetResult(&meshResults).
+			Post(accessFederationMeshEndpoint)
+
+		if err != nil {
+			utilfw.UnableToUpdateResourceError(resp, err.Error())
+			return
+		}
+
+		if response.IsError() {
+			utilfw.UnableToUpdateResourceError(resp, response.String())
+			return
+		}
+		results = append(results, meshResults...)
 	}
 
 	for _, result := range results {
@@ -311,17 +737,134 @@ func (r *accessFederationMeshResource) Update(ctx context.Context, req resource.
 		})
 	}
 
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+
 	var ids []string
 	resp.Diagnostics.Append(plan.IDs.ElementsAs(ctx, &ids, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 	plan.ID = types.StringValue(strings.Join(ids, ":"))
+	plan.MissingLinks = types.ListValueMust(meshLinkElementType, []attr.Value{})
+	plan.EntityMismatches = types.ListValueMust(meshEntityMismatchElementType, []attr.Value{})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed links only after saving the state, as the mesh may have
+	// been partially created
+	resp.Diagnostics.Append(resultsDiags...)
+}
+
+// deleteMeshLinks tears down the links, e.g. involving members removed from
+// the mesh.
+func (r *accessFederationMeshResource) deleteMeshLinks(ctx context.Context, links []meshLink) (results []accessFederationResponseAPIModel, ds diag.Diagnostics) {
+	for _, link := range links {
+		tflog.Info(ctx, "Deleting mesh link", map[string]interface{}{
+			"source": link.SourceID,
+			"target": link.TargetID,
+		})
+
+		res, err := deleteAccessFederationLink(r.ProviderData.Client, link.SourceID, link.TargetID)
+		if err != nil {
+			ds.AddError("Unable to delete Access Federation link", err.Error())
+			return
+		}
+		results = append(results, res...)
+	}
+
+	return
+}
+
+// createMeshLinks creates the links, e.g. involving members added to the mesh,
+// or reported missing or mismatched in state. Links between existing members
+// are left as is.
+func (r *accessFederationMeshResource) createMeshLinks(ctx context.Context, linksToCreate []meshLink, entities []string) (results []accessFederationResponseAPIModel, ds diag.Diagnostics) {
+	accessURLs := map[string]string{}
+	for _, link := range linksToCreate {
+		accessURL, ok := accessURLs[link.TargetID]
+		if !ok {
+			jpd, err := getJPD(r.ProviderData.Client, link.TargetID)
+			if err != nil {
+				ds.AddError("Unable to read Platform Deployment", fmt.Sprintf("%s: %s", link.TargetID, err))
+				return
+			}
+			accessURL = jpd.accessURL()
+			accessURLs[link.TargetID] = accessURL
+		}
+
+		tflog.Info(ctx, "Creating mesh link", map[string]interface{}{
+			"source": link.SourceID,
+			"target": link.TargetID,
+		})
+
+		res, err := putAccessFederationLink(
+			r.ProviderData.Client,
+			link.SourceID,
+			accessFederationTargetAPIModel{
+				ID:       link.TargetID,
+				URL:      accessURL,
+				Entities: entities,
+			},
+		)
+		if err != nil {
+			ds.AddError("Unable to create Access Federation link", err.Error())
+			return
+		}
+		results = append(results, res...)
+	}
+
+	return
+}
+
+// meshMembershipChanges returns the links to create and delete when the mesh
+// members change from oldIDs to newIDs. Links to repair between remaining
+// members are created again.
+func meshMembershipChanges(oldIDs, newIDs []string, linksToRepair []meshLink) (linksToCreate, linksToDelete []meshLink) {
+	addedIDs, removedIDs := lo.Difference(newIDs, oldIDs)
+
+	newMembers := slices.Clone(newIDs)
+	slices.Sort(newMembers)
+	for _, sourceID := range newMembers {
+		for _, targetID := range newMembers {
+			if sourceID == targetID {
+				continue
+			}
+
+			link := meshLink{SourceID: sourceID, TargetID: targetID}
+			needsRepair := lo.ContainsBy(linksToRepair, func(l meshLink) bool {
+				return l.SourceID == sourceID && l.TargetID == targetID
+			})
+
+			if lo.Contains(addedIDs, sourceID) || lo.Contains(addedIDs, targetID) || needsRepair {
+				linksToCreate = append(linksToCreate, link)
+			}
+		}
+	}
+
+	oldMembers := slices.Clone(oldIDs)
+	slices.Sort(oldMembers)
+	for _, sourceID := range oldMembers {
+		for _, targetID := range oldMembers {
+			if sourceID == targetID {
+				continue
+			}
+
+			if lo.Contains(removedIDs, sourceID) || lo.Contains(removedIDs, targetID) {
+				linksToDelete = append(linksToDelete, meshLink{SourceID: sourceID, TargetID: targetID})
+			}
+		}
+	}
+
+	return
 }
 
 func (r *accessFederationMeshResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	resp.Diagnostics.AddWarning(
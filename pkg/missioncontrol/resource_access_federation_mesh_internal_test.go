@@ -0,0 +1,113 @@
+package missioncontrol
+
+import (
+	"reflect"
+	"testing"
+)
+
+func meshTarget(id string, entities ...string) accessFederationTargetGetAllAPIModel {
+	return accessFederationTargetGetAllAPIModel{
+		accessFederationTargetAPIModel: accessFederationTargetAPIModel{
+			ID:       id,
+			URL:      "https://" + id + "/access",
+			Entities: entities,
+		},
+	}
+}
+
+func TestEvaluateMeshConnectivity(t *testing.T) {
+	accessFederations := []accessFederationGetAllResponseAPIModel{
+		{
+			Source:   "JPD-1",
+			Entities: []string{"USERS", "GROUPS"},
+			Targets: []accessFederationTargetGetAllAPIModel{
+				// no entities of its own, syncs the source entities
+				meshTarget("JPD-2"),
+				meshTarget("JPD-3", "GROUPS", "USERS"),
+			},
+		},
+		{
+			Source:   "JPD-2",
+			Entities: []string{"USERS", "GROUPS"},
+			Targets: []accessFederationTargetGetAllAPIModel{
+				meshTarget("JPD-1", "USERS"),
+			},
+		},
+		{
+			Source:   "JPD-3",
+			Entities: []string{"USERS", "GROUPS"},
+			Targets: []accessFederationTargetGetAllAPIModel{
+				meshTarget("JPD-1"),
+				meshTarget("JPD-2"),
+			},
+		},
+	}
+
+	connectivity := evaluateMeshConnectivity([]string{"JPD-3", "JPD-1", "JPD-2"}, []string{"GROUPS", "USERS"}, accessFederations)
+
+	expectedMissingLinks := []meshLink{
+		{SourceID: "JPD-2", TargetID: "JPD-3"},
+	}
+	if !reflect.DeepEqual(connectivity.MissingLinks, expectedMissingLinks) {
+		t.Errorf("expected missing links %v, got %v", expectedMissingLinks, connectivity.MissingLinks)
+	}
+
+	expectedEntityMismatches := []meshLink{
+		{SourceID: "JPD-2", TargetID: "JPD-1", Entities: []string{"USERS"}},
+	}
+	if !reflect.DeepEqual(connectivity.EntityMismatches, expectedEntityMismatches) {
+		t.Errorf("expected entity mismatches %v, got %v", expectedEntityMismatches, connectivity.EntityMismatches)
+	}
+
+	if len(connectivity.Links) != 5 {
+		t.Errorf("expected 5 links, got %v", connectivity.Links)
+	}
+}
+
+func TestEvaluateMeshConnectivity_entitiesNotEvaluated(t *testing.T) {
+	accessFederations := []accessFederationGetAllResponseAPIModel{
+		{
+			Source:  "JPD-1",
+			Targets: []accessFederationTargetGetAllAPIModel{meshTarget("JPD-2", "USERS")},
+		},
+		{
+			Source:  "JPD-2",
+			Targets: []accessFederationTargetGetAllAPIModel{meshTarget("JPD-1")},
+		},
+	}
+
+	connectivity := evaluateMeshConnectivity([]string{"JPD-1", "JPD-2"}, nil, accessFederations)
+
+	if len(connectivity.MissingLinks) != 0 || len(connectivity.EntityMismatches) != 0 {
+		t.Errorf("expected no missing link nor entity mismatch, got %v and %v", connectivity.MissingLinks, connectivity.EntityMismatches)
+	}
+}
+
+func TestMeshMembershipChanges(t *testing.T) {
+	linksToCreate, linksToDelete := meshMembershipChanges(
+		[]string{"JPD-1", "JPD-2", "JPD-3"},
+		[]string{"JPD-1", "JPD-2", "JPD-4"},
+		[]meshLink{{SourceID: "JPD-2", TargetID: "JPD-1"}},
+	)
+
+	expectedLinksToCreate := []meshLink{
+		{SourceID: "JPD-1", TargetID: "JPD-4"},
+		{SourceID: "JPD-2", TargetID: "JPD-1"},
+		{SourceID: "JPD-2", TargetID: "JPD-4"},
+		{SourceID: "JPD-4", TargetID: "JPD-1"},
+		{SourceID: "JPD-4", TargetID: "JPD-2"},
+	}
+	if !reflect.DeepEqual(linksToCreate, expectedLinksToCreate) {
+		t.Errorf("expected links to create %v, got %v", expectedLinksToCreate, linksToCreate)
+	}
+
+	expectedLinksToDelete := []meshLink{
+		{SourceID: "JPD-1", TargetID: "JPD-3"},
+		{SourceID: "JPD-2", TargetID: "JPD-3"},
+		{SourceID: "JPD-3", TargetID: "JPD-1"},
+		{SourceID: "JPD-3", TargetID: "JPD-2"},
+	}
+	if !reflect.DeepEqual(linksToDelete, expectedLinksToDelete) {
+		t.Errorf("expected links to delete %v, got %v", expectedLinksToDelete, linksToDelete)
+	}
+}
@@ -2,6 +2,7 @@ package missioncontrol_test
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -21,7 +22,7 @@ func TestAccAccessFederationMesh_full(t *testing.T) {
 	}
 
 	if skip, reason := skipTest(); skip {
-		t.Skipf(reason)
+		t.Skip(reason)
 	}
 
 	_, fqrn, resourceName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_mesh")
@@ -60,6 +61,10 @@ func TestAccAccessFederationMesh_full(t *testing.T) {
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "GROUPS"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "PERMISSIONS"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "TOKENS"),
+					resource.TestCheckResourceAttr(fqrn, "allow_partial_failure", "false"),
+					resource.TestCheckResourceAttrSet(fqrn, "last_operation_results.#"),
+					resource.TestCheckResourceAttr(fqrn, "missing_links.#", "0"),
+					resource.TestCheckResourceAttr(fqrn, "entity_mismatches.#", "0"),
 				),
 			},
 			{
@@ -75,10 +80,71 @@ func TestAccAccessFederationMesh_full(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      fqrn,
-				ImportState:       true,
-				ImportStateId:     "JPD-1:JPD-2",
-				ImportStateVerify: true,
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateId:           "JPD-1:JPD-2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_operation_results"},
+			},
+		},
+	})
+}
+
+// To execute this test, you need setup a third Artifactory instance with
+// circle-of-trust, registered as JPD-3. Then set it as env var before running
+// the test
+func TestAccAccessFederationMesh_existingFederation(t *testing.T) {
+	var skipTest = func() (bool, string) {
+		if len(os.Getenv("ARTIFACTORY_URL_2")) > 0 && len(os.Getenv("ARTIFACTORY_URL_3")) > 0 {
+			return false, "Env vars `ARTIFACTORY_URL_2` and `ARTIFACTORY_URL_3` are set. Executing test."
+		}
+
+		return true, "Env vars `ARTIFACTORY_URL_2` and `ARTIFACTORY_URL_3` are not set. Skipping test."
+	}
+
+	if skip, reason := skipTest(); skip {
+		t.Skip(reason)
+	}
+
+	_, _, targetName := testutil.MkNames("test-access-federation-target", "missioncontrol_access_federation_target")
+	_, _, meshName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_mesh")
+
+	targetTemp := `
+	resource "missioncontrol_access_federation_target" "{{ .targetName }}" {
+		source_id  = "JPD-1"
+		target_id  = "JPD-3"
+		target_url = "{{ .targetURL }}/access"
+		entities   = ["USERS", "GROUPS"]
+	}`
+
+	testData := map[string]string{
+		"targetName": targetName,
+		"meshName":   meshName,
+		"targetURL":  os.Getenv("ARTIFACTORY_URL_3"),
+	}
+
+	targetConfig := util.ExecuteTemplate(targetName, targetTemp, testData)
+
+	meshTemp := targetTemp + `
+
+	resource "missioncontrol_access_federation_mesh" "{{ .meshName }}" {
+		ids      = ["JPD-1", "JPD-2"]
+		entities = ["USERS", "GROUPS"]
+
+		depends_on = [missioncontrol_access_federation_target.{{ .targetName }}]
+	}`
+	meshConfig := util.ExecuteTemplate(meshName, meshTemp, testData)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: targetConfig,
+			},
+			{
+				Config:      meshConfig,
+				ExpectError: regexp.MustCompile(".*Members already federate outside of this mesh.*"),
 			},
 		},
 	})
@@ -2,8 +2,15 @@ package missioncontrol
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"reflect"
 	"regexp"
+	"slices"
+	"strings"
+	"sync"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -11,6 +18,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -22,10 +31,174 @@ import (
 
 const accessFederationEndpoint = "mc/api/v1/federation/{id}"
 
+const (
+	targetManagementExclusive = "exclusive"
+	targetManagementAdditive  = "additive"
+)
+
+// accessFederationSourceLocks serializes read-modify-write of the federation
+// configuration of a source JPD, keyed by source JPD ID.
+var accessFederationSourceLocks sync.Map
+
+func lockAccessFederationSource(sourceID string) func() {
+	lock, _ := accessFederationSourceLocks.LoadOrStore(sourceID, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
+// getAccessFederation fetches the federation configuration of the source JPD.
+func getAccessFederation(client *resty.Client, sourceID string) (*accessFederationGetResponseAPIModel, error) {
+	var accessFederation accessFederationGetResponseAPIModel
+	response, err := client.R().
+		SetPathParam("id", sourceID).
+		SetResult(&accessFederation).
+		Get(accessFederationEndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.IsError() {
+		return nil, fmt.Errorf("%s", response.String())
+	}
+
+	return &accessFederation, nil
+}
+
+// putAccessFederation replaces the federation configuration of the source JPD.
+func putAccessFederation(client *resty.Client, accessFederation accessFederationRequestAPIModel) ([]accessFederationResponseAPIModel, error) {
+	var results []accessFederationResponseAPIModel
+	response, err := client.R().
+		SetPathParam("id", accessFederation.ID).
+		SetBody(accessFederation).
+		SetResult(&results).
+		Put(accessFederationEndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.IsError() {
+		return nil, fmt.Errorf("%s", response.String())
+	}
+
+	return results, nil
+}
+
+// accessFederationUpdateAttempts is the number of times the read-modify-write
+// of the federation configuration of a source is started over when the
+// configuration changes in the meantime.
+const accessFederationUpdateAttempts = 3
+
+// updateAccessFederation applies update to the federation configuration of the
+// source JPD. update returns nil when there is nothing to change.
+//
+// The lock only serializes the resources of this provider process. The
+// configuration is therefore read again right before writing, and the update
+// starts over when it was changed in the meantime, e.g. by another Terraform
+// run. Mission Control has no conditional write, so a change made between the
+// second read and the write can still be lost.
+func updateAccessFederation(client *resty.Client, sourceID string, update func(*accessFederationGetResponseAPIModel) *accessFederationRequestAPIModel) ([]accessFederationResponseAPIModel, error) {
+	unlock := lockAccessFederationSource(sourceID)
+	defer unlock()
+
+	for attempt := 1; ; attempt++ {
+		accessFederation, err := getAccessFederation(client, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read Access Federation configuration of %s: %w", sourceID, err)
+		}
+
+		request := update(accessFederation)
+		if request == nil {
+			return nil, nil
+		}
+
+		current, err := getAccessFederation(client, sourceID)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read Access Federation configuration of %s: %w", sourceID, err)
+		}
+
+		if !reflect.DeepEqual(accessFederation, current) {
+			if attempt < accessFederationUpdateAttempts {
+				continue
+			}
+
+			return nil, fmt.Errorf("Access Federation configuration of %s keeps being changed concurrently, e.g. by another Terraform run. Retry once the other changes completed", sourceID)
+		}
+
+		results, err := putAccessFederation(client, *request)
+		if err != nil {
+			return nil, fmt.Errorf("unable to update Access Federation configuration of %s: %w", sourceID, err)
+		}
+
+		return results, nil
+	}
+}
+
+// putAccessFederationLink creates, or replaces, the link from the source JPD to
+// a single target. Other targets of the source are left as is.
+func putAccessFederationLink(client *resty.Client, sourceID string, target accessFederationTargetAPIModel) ([]accessFederationResponseAPIModel, error) {
+	return updateAccessFederation(
+		client,
+		sourceID,
+		func(accessFederation *accessFederationGetResponseAPIModel) *accessFederationRequestAPIModel {
+			targets := lo.Reject(
+				accessFederation.Targets,
+				func(t accessFederationTargetAPIModel, _ int) bool {
+					return t.ID == target.ID
+				},
+			)
+
+			// a source without any federation yet has no entities of its own
+			entities := accessFederation.Entities
+			if len(entities) == 0 {
+				entities = target.Entities
+			}
+
+			return &accessFederationRequestAPIModel{
+				ID:       sourceID,
+				Entities: entities,
+				Targets:  append(targets, target),
+			}
+		},
+	)
+}
+
+// deleteAccessFederationLink removes the link from the source JPD to a single
+// target. Other targets of the source are left as is.
+func deleteAccessFederationLink(client *resty.Client, sourceID, targetID string) ([]accessFederationResponseAPIModel, error) {
+	return updateAccessFederation(
+		client,
+		sourceID,
+		func(accessFederation *accessFederationGetResponseAPIModel) *accessFederationRequestAPIModel {
+			targets := lo.Reject(
+				accessFederation.Targets,
+				func(t accessFederationTargetAPIModel, _ int) bool {
+					return t.ID == targetID
+				},
+			)
+			if len(targets) == len(accessFederation.Targets) {
+				// link is already gone
+				return nil
+			}
+
+			return &accessFederationRequestAPIModel{
+				ID:       sourceID,
+				Entities: accessFederation.Entities,
+				Targets:  targets,
+			}
+		},
+	)
+}
+
 var _ resource.Resource = &accessFederationStarResource{}
+var _ resource.ResourceWithModifyPlan = &accessFederationStarResource{}
+var _ resource.ResourceWithConfigValidators = &accessFederationStarResource{}
 
 type accessFederationStarResource struct {
-	ProviderData util.ProviderMetadata
+	ProviderData ProviderMetadata
 	TypeName     string
 }
 
@@ -43,49 +216,87 @@ func (r *accessFederationStarResource) Schema(ctx context.Context, req resource.
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required: true,
+				Optional: true,
+				Computed: true,
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("name")),
 				},
-				Description: "ID for the source Platform Deployment. Use [Get Access Federation Candidate API](https://jfrog.com/help/r/jfrog-rest-apis/get-access-federation-candidates) to get a list of ID.",
+				Description: "ID for the source Platform Deployment. Use [Get Access Federation Candidate API](https://jfrog.com/help/r/jfrog-rest-apis/get-access-federation-candidates) to get a list of ID. Either `id` or `name` must be set. When `name` is set, this is the resolved ID.",
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				Description: "Name of the source Platform Deployment, resolved to `id` with Mission Control during plan. Unlike IDs, names can be the same across Mission Control instances, e.g. staging and production.",
 			},
 			"entities": schema.SetAttribute{
 				ElementType: types.StringType,
 				Required:    true,
 				Validators: []validator.Set{
 					setvalidator.ValueStringsAre(
-						stringvalidator.OneOf("USERS", "GROUPS", "PERMISSIONS", "TOKENS"),
+						isEntityType(),
 					),
 				},
-				Description: "Entity types to sync. Allow values: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`",
+				Description: "Entity types to sync. For example: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`. The entity types are validated against the platform version during plan.",
 			},
 			"targets": schema.SetNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
-							Required: true,
+							Optional: true,
+							Computed: true,
 							Validators: []validator.String{
 								stringvalidator.LengthAtLeast(1),
+								stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("name")),
 							},
-							Description: "ID of the targeted Platform Deployment",
+							Description: "ID of the targeted Platform Deployment. Either `id` or `name` must be set. When `name` is set, this is the resolved ID.",
+						},
+						"name": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							Description: "Name of the targeted Platform Deployment, resolved to `id` with Mission Control during plan.",
 						},
 						"url": schema.StringAttribute{
-							Required: true,
+							Optional: true,
+							Computed: true,
 							Validators: []validator.String{
 								validator_string.IsURLHttpOrHttps(),
 								stringvalidator.RegexMatches(regexp.MustCompile(`^.+/access$`), "must end in '/access'"),
 							},
-							Description: "Target Platform deployment URL: http://<hostname>:<port>/access; for example: http://myplatformserver:8082/access.",
+							Description: "Target Platform deployment URL: http://<hostname>:<port>/access; for example: http://myplatformserver:8082/access. When not set, the Access URL is derived from the `base_url` of the target registered in Mission Control.",
+						},
+						"entities": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(
+									isEntityType(),
+								),
+							},
+							Description: "Entity types to sync to this target, overriding the top level `entities`. For example: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`. The entity types are validated against the platform version during plan.",
 						},
 						"permission_filters": schema.SingleNestedAttribute{
 							Attributes: map[string]schema.Attribute{
 								"include_patterns": schema.SetAttribute{
 									ElementType: types.StringType,
 									Optional:    true,
+									Validators: []validator.Set{
+										setvalidator.ValueStringsAre(isPermissionFilterPattern()),
+									},
+									Description: "Regular expressions of permission names to be synchronized. A pattern can't be both included and excluded.",
 								},
 								"exclude_patterns": schema.SetAttribute{
 									ElementType: types.StringType,
 									Optional:    true,
+									Validators: []validator.Set{
+										setvalidator.ValueStringsAre(isPermissionFilterPattern()),
+									},
+									Description: "Regular expressions of permission names to be excluded from synchronization. A pattern can't be both included and excluded.",
 								},
 							},
 							Optional:    true,
@@ -96,6 +307,17 @@ func (r *accessFederationStarResource) Schema(ctx context.Context, req resource.
 				Required:    true,
 				Description: "Target JPD",
 			},
+			"allow_partial_failure":  allowPartialFailureSchemaAttribute,
+			"last_operation_results": operationResultsSchemaAttribute,
+			"target_management": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(targetManagementExclusive),
+				Validators: []validator.String{
+					stringvalidator.OneOf(targetManagementExclusive, targetManagementAdditive),
+				},
+				MarkdownDescription: "How targets of the source not in `targets` are handled. `exclusive` replaces all targets of the source with `targets`, removing any target added outside of this resource. `additive` keeps these unmanaged targets as is, and ignores them when reading. Allow values: `exclusive`, `additive`. Default to `exclusive`.",
+			},
 		},
 		MarkdownDescription: "Provides a [JFrog Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation) resource to setup Star Topology.\n\n" +
 			"~>The source and targets must have been configured properly for [Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation).\n\n" +
@@ -104,14 +326,29 @@ func (r *accessFederationStarResource) Schema(ctx context.Context, req resource.
 }
 
 type accessFederationStarResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Entities types.Set    `tfsdk:"entities"`
-	Targets  types.Set    `tfsdk:"targets"`
+	ID                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Entities             types.Set    `tfsdk:"entities"`
+	Targets              types.Set    `tfsdk:"targets"`
+	TargetManagement     types.String `tfsdk:"target_management"`
+	AllowPartialFailure  types.Bool   `tfsdk:"allow_partial_failure"`
+	LastOperationResults types.List   `tfsdk:"last_operation_results"`
+}
+
+func (r accessFederationStarResourceModel) targetIDs() []string {
+	return lo.Map(
+		r.Targets.Elements(),
+		func(elem attr.Value, _ int) string {
+			return elem.(types.Object).Attributes()["id"].(types.String).ValueString()
+		},
+	)
 }
 
 var targetAttributeTypes = map[string]attr.Type{
 	"id":                 types.StringType,
+	"name":               types.StringType,
 	"url":                types.StringType,
+	"entities":           types.SetType{ElemType: types.StringType},
 	"permission_filters": types.ObjectType{AttrTypes: permissionFilterAttributeTypes},
 }
 
@@ -119,52 +356,331 @@ var targetsElmementType = types.ObjectType{
 	AttrTypes: targetAttributeTypes,
 }
 
+// resolveNames sets the ID of the source, and of the targets, configured by
+// name.
+func (r *accessFederationStarResourceModel) resolveNames(client *resty.Client) error {
+	var names []string
+	if r.ID.IsUnknown() && !r.Name.IsNull() && !r.Name.IsUnknown() {
+		names = append(names, r.Name.ValueString())
+	}
+
+	if !r.Targets.IsNull() && !r.Targets.IsUnknown() {
+		for _, elem := range r.Targets.Elements() {
+			attrs := elem.(types.Object).Attributes()
+			name := attrs["name"].(types.String)
+			if attrs["id"].IsUnknown() && !name.IsNull() && !name.IsUnknown() {
+				names = append(names, name.ValueString())
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	ids, err := resolveJPDNames(client, names)
+	if err != nil {
+		return err
+	}
+
+	if r.ID.IsUnknown() && !r.Name.IsNull() && !r.Name.IsUnknown() {
+		r.ID = types.StringValue(ids[r.Name.ValueString()])
+	}
+
+	if r.Targets.IsNull() || r.Targets.IsUnknown() {
+		return nil
+	}
+
+	targets := lo.Map(
+		r.Targets.Elements(),
+		func(elem attr.Value, _ int) attr.Value {
+			target := elem.(types.Object)
+			attrs := target.Attributes()
+			id, ok := ids[attrs["name"].(types.String).ValueString()]
+			if !attrs["id"].IsUnknown() || !ok {
+				return target
+			}
+
+			resolvedAttrs := maps.Clone(attrs)
+			resolvedAttrs["id"] = types.StringValue(id)
+
+			return types.ObjectValueMust(targetAttributeTypes, resolvedAttrs)
+		},
+	)
+
+	targetsSet, d := types.SetValue(targetsElmementType, targets)
+	if d.HasError() {
+		return fmt.Errorf("unable to set ID of targets")
+	}
+	r.Targets = targetsSet
+
+	return nil
+}
+
+// resolveTargetURLs sets the URL of each target still unknown during apply,
+// i.e. whose ID wasn't known during plan, to the Access URL of the target JPD
+// registered in Mission Control.
+func resolveTargetURLs(client *resty.Client, targets types.Set) (types.Set, error) {
+	if targets.IsNull() || targets.IsUnknown() {
+		return targets, nil
+	}
+
+	accessURLs := map[string]string{}
+	resolved := make([]attr.Value, 0, len(targets.Elements()))
+	for _, elem := range targets.Elements() {
+		target := elem.(types.Object)
+		attrs := target.Attributes()
+
+		id := attrs["id"].(types.String)
+		if !attrs["url"].IsUnknown() || id.IsUnknown() {
+			resolved = append(resolved, target)
+			continue
+		}
+
+		accessURL, ok := accessURLs[id.ValueString()]
+		if !ok {
+			jpd, err := getJPD(client, id.ValueString())
+			if err != nil {
+				return targets, fmt.Errorf("unable to read Platform Deployment %s: %w", id.ValueString(), err)
+			}
+			accessURL = jpd.accessURL()
+			accessURLs[id.ValueString()] = accessURL
+		}
+
+		resolvedAttrs := maps.Clone(attrs)
+		resolvedAttrs["url"] = types.StringValue(accessURL)
+
+		t, d := types.ObjectValue(targetAttributeTypes, resolvedAttrs)
+		if d.HasError() {
+			return targets, fmt.Errorf("unable to set URL of target %s", id.ValueString())
+		}
+		resolved = append(resolved, t)
+	}
+
+	resolvedSet, d := types.SetValue(targetsElmementType, resolved)
+	if d.HasError() {
+		return targets, fmt.Errorf("unable to set URL of targets")
+	}
+
+	return resolvedSet, nil
+}
+
+// planTargetURLs sets the URL of each target without one to the Access URL of
+// the target JPD registered in Mission Control, and warns about each
+// configured URL which doesn't match it. Each JPD is read once per plan.
+// Targets whose ID is not known yet are left for resolveTargetURLs during
+// apply.
+func planTargetURLs(ctx context.Context, client *resty.Client, targets types.Set) (types.Set, diag.Diagnostics) {
+	var ds diag.Diagnostics
+	if targets.IsNull() || targets.IsUnknown() {
+		return targets, ds
+	}
+
+	jpds := map[string]*jpdGetResponseAPIModel{}
+	errs := map[string]error{}
+	getTargetJPD := func(id string) (*jpdGetResponseAPIModel, error) {
+		if _, ok := jpds[id]; !ok {
+			jpds[id], errs[id] = getJPD(client, id)
+		}
+		return jpds[id], errs[id]
+	}
+
+	planned := make([]attr.Value, 0, len(targets.Elements()))
+	for _, elem := range targets.Elements() {
+		target := elem.(types.Object)
+		attrs := target.Attributes()
+
+		id := attrs["id"].(types.String)
+		url := attrs["url"].(types.String)
+		if id.IsUnknown() || url.IsNull() {
+			planned = append(planned, target)
+			continue
+		}
+
+		jpd, err := getTargetJPD(id.ValueString())
+
+		if !url.IsUnknown() {
+			if err != nil {
+				tflog.Debug(ctx, "Unable to read Platform Deployment of target", map[string]interface{}{
+					"target": id.ValueString(),
+					"error":  err.Error(),
+				})
+			} else if accessURL := jpd.accessURL(); strings.TrimSuffix(url.ValueString(), "/") != accessURL {
+				ds.AddAttributeWarning(
+					path.Root("targets").AtSetValue(target).AtName("url"),
+					"Target URL does not match Platform Deployment",
+					fmt.Sprintf("URL %s of target %s does not match %s, the Access URL of the Platform Deployment registered in Mission Control. Remove `url` to use the registered one.", url.ValueString(), id.ValueString(), accessURL),
+				)
+			}
+
+			planned = append(planned, target)
+			continue
+		}
+
+		if err != nil {
+			ds.AddAttributeError(
+				path.Root("targets").AtSetValue(target).AtName("url"),
+				"Unable to resolve target URL",
+				fmt.Sprintf("unable to read Platform Deployment %s: %s", id.ValueString(), err),
+			)
+			continue
+		}
+
+		plannedAttrs := maps.Clone(attrs)
+		plannedAttrs["url"] = types.StringValue(jpd.accessURL())
+
+		t, d := types.ObjectValue(targetAttributeTypes, plannedAttrs)
+		ds.Append(d...)
+		planned = append(planned, t)
+	}
+
+	if ds.HasError() {
+		return targets, ds
+	}
+
+	plannedSet, d := types.SetValue(targetsElmementType, planned)
+	ds.Append(d...)
+
+	return plannedSet, ds
+}
+
 var permissionFilterAttributeTypes = map[string]attr.Type{
 	"include_patterns": types.SetType{ElemType: types.StringType},
 	"exclude_patterns": types.SetType{ElemType: types.StringType},
 }
 
+// permissionFiltersFromAPIModel converts the permission filters of a target.
+// As the API doesn't distinguish between an omitted block, an empty block, and
+// an empty pattern set, the prior state/plan value is kept in these cases so
+// the configuration round-trips without a diff.
+func permissionFiltersFromAPIModel(ctx context.Context, apiModel *accessFederationPermissionFiltersAPIModel, prior types.Object) (types.Object, diag.Diagnostics) {
+	if apiModel.isDefault() && (prior.IsNull() || prior.IsUnknown()) {
+		return types.ObjectNull(permissionFilterAttributeTypes), nil
+	}
+
+	if apiModel == nil {
+		apiModel = &accessFederationPermissionFiltersAPIModel{}
+	}
+
+	var priorAttrs map[string]attr.Value
+	if !prior.IsNull() && !prior.IsUnknown() {
+		priorAttrs = prior.Attributes()
+	}
+
+	ds := diag.Diagnostics{}
+
+	patternsValue := func(attrName string, patterns []string) types.Set {
+		if len(patterns) == 0 {
+			// keep an explicitly empty set from the configuration
+			if p, ok := priorAttrs[attrName].(types.Set); ok && !p.IsNull() && !p.IsUnknown() && len(p.Elements()) == 0 {
+				return p
+			}
+			return types.SetNull(types.StringType)
+		}
+
+		p, d := types.SetValueFrom(ctx, types.StringType, patterns)
+		if d.HasError() {
+			ds.Append(d...)
+		}
+		return p
+	}
+
+	permissionFilters, d := types.ObjectValue(
+		permissionFilterAttributeTypes,
+		map[string]attr.Value{
+			"include_patterns": patternsValue("include_patterns", apiModel.IncludePatterns),
+			"exclude_patterns": patternsValue("exclude_patterns", apiModel.ExcludePatterns),
+		},
+	)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+
+	return permissionFilters, ds
+}
+
+// permissionFiltersToAPIModel converts the permission filters of a target. An
+// omitted block is not sent so the server defaults apply.
+func permissionFiltersToAPIModel(ctx context.Context, permissionFilters types.Object) (*accessFederationPermissionFiltersAPIModel, diag.Diagnostics) {
+	if permissionFilters.IsNull() || permissionFilters.IsUnknown() {
+		return nil, nil
+	}
+
+	ds := diag.Diagnostics{}
+	apiModel := accessFederationPermissionFiltersAPIModel{}
+
+	attrs := permissionFilters.Attributes()
+	if p, ok := attrs["include_patterns"].(types.Set); ok && !p.IsNull() && !p.IsUnknown() {
+		ds.Append(p.ElementsAs(ctx, &apiModel.IncludePatterns, false)...)
+	}
+	if p, ok := attrs["exclude_patterns"].(types.Set); ok && !p.IsNull() && !p.IsUnknown() {
+		ds.Append(p.ElementsAs(ctx, &apiModel.ExcludePatterns, false)...)
+	}
+
+	return &apiModel, ds
+}
+
 func (r *accessFederationStarResourceModel) fromAPIModel(ctx context.Context, apiModel *accessFederationGetResponseAPIModel) (ds diag.Diagnostics) {
+	// targets with entities override, and permission filters, from prior
+	// state/plan, keyed by target ID
+	targetEntitiesOverrides := map[string]bool{}
+	priorPermissionFilters := map[string]types.Object{}
+	targetNames := map[string]types.String{}
+	if !r.Targets.IsNull() && !r.Targets.IsUnknown() {
+		for _, elem := range r.Targets.Elements() {
+			attrs := elem.(types.Object).Attributes()
+			targetID := attrs["id"].(types.String).ValueString()
+			if name, ok := attrs["name"].(types.String); ok && !name.IsNull() {
+				targetNames[targetID] = name
+			}
+			if entities, ok := attrs["entities"].(types.Set); ok && !entities.IsNull() {
+				targetEntitiesOverrides[targetID] = true
+			}
+			if permissionFilters, ok := attrs["permission_filters"].(types.Object); ok {
+				priorPermissionFilters[targetID] = permissionFilters
+			}
+		}
+	}
+
 	r.Targets = types.SetNull(targetsElmementType)
 
 	if len(apiModel.Targets) > 0 {
 		targets := lo.Map(
 			apiModel.Targets,
 			func(target accessFederationTargetAPIModel, _ int) attr.Value {
-				includePatterns := types.SetNull(types.StringType)
-				if len(target.PermissionFilters.IncludePatterns) > 0 {
-					p, d := types.SetValueFrom(ctx, types.StringType, target.PermissionFilters.IncludePatterns)
-					if d.HasError() {
-						ds.Append(d...)
-					}
-					includePatterns = p
+				prior, ok := priorPermissionFilters[target.ID]
+				if !ok {
+					prior = types.ObjectNull(permissionFilterAttributeTypes)
+				}
+				permissionFilters, d := permissionFiltersFromAPIModel(ctx, target.PermissionFilters, prior)
+				if d.HasError() {
+					ds.Append(d...)
 				}
 
-				excludePatterns := types.SetNull(types.StringType)
-				if len(target.PermissionFilters.ExcludePatterns) > 0 {
-					p, d := types.SetValueFrom(ctx, types.StringType, target.PermissionFilters.ExcludePatterns)
+				// target entities are only an override if they differ from the
+				// top level entities, or were explicitly set before
+				entities := types.SetNull(types.StringType)
+				if len(target.Entities) > 0 &&
+					(targetEntitiesOverrides[target.ID] || !lo.Every(apiModel.Entities, target.Entities) || !lo.Every(target.Entities, apiModel.Entities)) {
+					e, d := types.SetValueFrom(ctx, types.StringType, target.Entities)
 					if d.HasError() {
 						ds.Append(d...)
 					}
-					excludePatterns = p
+					entities = e
 				}
 
-				permissionFilters, d := types.ObjectValue(
-					permissionFilterAttributeTypes,
-					map[string]attr.Value{
-						"include_patterns": includePatterns,
-						"exclude_patterns": excludePatterns,
-					},
-				)
-				if d.HasError() {
-					ds.Append(d...)
+				name, ok := targetNames[target.ID]
+				if !ok {
+					name = types.StringNull()
 				}
 
 				t, d := types.ObjectValue(
 					targetAttributeTypes,
 					map[string]attr.Value{
 						"id":                 types.StringValue(target.ID),
+						"name":               name,
 						"url":                types.StringValue(target.URL),
+						"entities":           entities,
 						"permission_filters": permissionFilters,
 					},
 				)
@@ -202,27 +718,22 @@ func (r accessFederationStarResourceModel) toAPIModel(ctx context.Context, apiMo
 		func(elem attr.Value, _ int) accessFederationTargetAPIModel {
 			attrs := elem.(types.Object).Attributes()
 
-			permissionFiltersAttrs := attrs["permission_filters"].(types.Object).Attributes()
-
-			var includePatterns []string
-			d := permissionFiltersAttrs["include_patterns"].(types.Set).ElementsAs(ctx, &includePatterns, false)
+			permissionFilters, d := permissionFiltersToAPIModel(ctx, attrs["permission_filters"].(types.Object))
 			if d.HasError() {
 				ds.Append(d...)
 			}
 
-			var excludePatterns []string
-			d = permissionFiltersAttrs["exclude_patterns"].(types.Set).ElementsAs(ctx, &excludePatterns, false)
+			var targetEntities []string
+			d = attrs["entities"].(types.Set).ElementsAs(ctx, &targetEntities, false)
 			if d.HasError() {
 				ds.Append(d...)
 			}
 
 			return accessFederationTargetAPIModel{
-				ID:  attrs["id"].(types.String).ValueString(),
-				URL: attrs["url"].(types.String).ValueString(),
-				PermissionFilters: accessFederationPermissionFiltersAPIModel{
-					IncludePatterns: includePatterns,
-					ExcludePatterns: excludePatterns,
-				},
+				ID:                attrs["id"].(types.String).ValueString(),
+				URL:               attrs["url"].(types.String).ValueString(),
+				Entities:          targetEntities,
+				PermissionFilters: permissionFilters,
 			}
 		},
 	)
@@ -243,14 +754,21 @@ type accessFederationRequestAPIModel struct {
 }
 
 type accessFederationTargetAPIModel struct {
-	ID                string                                    `json:"id"`
-	URL               string                                    `json:"url"`
-	PermissionFilters accessFederationPermissionFiltersAPIModel `json:"permission_filters"`
+	ID                string                                     `json:"id"`
+	URL               string                                     `json:"url"`
+	Entities          []string                                   `json:"entities,omitempty"`           // overrides top level entities when set
+	PermissionFilters *accessFederationPermissionFiltersAPIModel `json:"permission_filters,omitempty"` // server defaults apply when not set
 }
 
 type accessFederationPermissionFiltersAPIModel struct {
-	IncludePatterns []string `json:"include_patterns"`
-	ExcludePatterns []string `json:"exclude_patterns"`
+	IncludePatterns []string `json:"include_patterns,omitempty"`
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+}
+
+// isDefault returns true when no pattern is set, which is how Mission Control
+// reports a target without permission filters.
+func (m *accessFederationPermissionFiltersAPIModel) isDefault() bool {
+	return m == nil || (len(m.IncludePatterns) == 0 && len(m.ExcludePatterns) == 0)
 }
 
 type accessFederationResponseAPIModel struct {
@@ -258,20 +776,224 @@ type accessFederationResponseAPIModel struct {
 	Status string `json:"status"`
 }
 
+var operationResultAttributeTypes = map[string]attr.Type{
+	"label":  types.StringType,
+	"status": types.StringType,
+}
+
+var operationResultElementType = types.ObjectType{
+	AttrTypes: operationResultAttributeTypes,
+}
+
+var operationResultsSchemaAttribute = schema.ListNestedAttribute{
+	NestedObject: schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"label": schema.StringAttribute{
+				Computed: true,
+			},
+			"status": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+	},
+	Computed:    true,
+	Description: "Result for each target of the last create or update.",
+}
+
+var allowPartialFailureSchemaAttribute = schema.BoolAttribute{
+	Optional:            true,
+	Computed:            true,
+	Default:             booldefault.StaticBool(false),
+	MarkdownDescription: "When set to `true`, targets which failed to federate are reported as warnings instead of errors. Default to `false`.",
+}
+
+// accessFederationOperationResults converts the results of a federation
+// operation for the state, and reports every target without success status.
+func accessFederationOperationResults(results []accessFederationResponseAPIModel, allowPartialFailure bool) (types.List, diag.Diagnostics) {
+	ds := diag.Diagnostics{}
+
+	values := lo.Map(
+		results,
+		func(result accessFederationResponseAPIModel, _ int) attr.Value {
+			v, d := types.ObjectValue(
+				operationResultAttributeTypes,
+				map[string]attr.Value{
+					"label":  types.StringValue(result.Label),
+					"status": types.StringValue(result.Status),
+				},
+			)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			return v
+		},
+	)
+	list, d := types.ListValue(operationResultElementType, values)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+
+	failures := lo.FilterMap(
+		results,
+		func(result accessFederationResponseAPIModel, _ int) (string, bool) {
+			return fmt.Sprintf("%s: %s", result.Label, result.Status), !strings.EqualFold(result.Status, "success")
+		},
+	)
+	if len(failures) > 0 {
+		summary := "Access Federation partially failed"
+		detail := fmt.Sprintf("Federation did not succeed for %d target(s):\n%s", len(failures), strings.Join(failures, "\n"))
+		if allowPartialFailure {
+			ds.AddWarning(summary, detail)
+		} else {
+			ds.AddError(summary, detail+"\n\nSet `allow_partial_failure` to `true` to report these as warnings.")
+		}
+	}
+
+	return list, ds
+}
+
 type accessFederationGetResponseAPIModel struct {
 	Entities []string                         `json:"entities"`
 	Targets  []accessFederationTargetAPIModel `json:"targets"`
 }
 
+func (r *accessFederationStarResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		permissionFiltersConfigValidator{},
+	}
+}
+
 func (r *accessFederationStarResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
-	r.ProviderData = req.ProviderData.(util.ProviderMetadata)
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *accessFederationStarResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan accessFederationStarResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.ProviderData.capabilities.RequireAccessFederation(ctx, plan.Entities, path.Root("entities"))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Targets.IsUnknown() {
+		for _, elem := range plan.Targets.Elements() {
+			entities := elem.(types.Object).Attributes()["entities"].(types.Set)
+			if entities.IsNull() || entities.Equal(plan.Entities) {
+				continue
+			}
+
+			resp.Diagnostics.Append(r.ProviderData.capabilities.RequireAccessFederation(ctx, entities, path.Root("targets").AtSetValue(elem).AtName("entities"))...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// show the IDs resolved from names in the plan
+	if r.ProviderData.Client != nil {
+		id, targets := plan.ID, plan.Targets
+		if err := plan.resolveNames(r.ProviderData.Client); err != nil {
+			resp.Diagnostics.AddError("Unable to resolve Platform Deployment names", err.Error())
+			return
+		}
+
+		if !plan.ID.Equal(id) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), plan.ID)...)
+		}
+		if !plan.Targets.Equal(targets) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("targets"), plan.Targets)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// show the derived URL of targets in the plan, so it is only left unknown
+	// for targets whose ID is not known until apply
+	if r.ProviderData.Client != nil {
+		targets, d := planTargetURLs(ctx, r.ProviderData.Client, plan.Targets)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !targets.Equal(plan.Targets) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("targets"), targets)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	}
+}
+
+// mergeUnmanagedTargets returns accessFederation with the current targets of
+// the source appended when they are neither planned nor previously managed by
+// this resource, so the PUT doesn't remove them.
+func mergeUnmanagedTargets(accessFederation accessFederationRequestAPIModel, current *accessFederationGetResponseAPIModel, previousTargetIDs []string) *accessFederationRequestAPIModel {
+	plannedTargetIDs := lo.Map(
+		accessFederation.Targets,
+		func(target accessFederationTargetAPIModel, _ int) string {
+			return target.ID
+		},
+	)
+
+	targets := slices.Clone(accessFederation.Targets)
+	for _, target := range current.Targets {
+		if lo.Contains(plannedTargetIDs, target.ID) || lo.Contains(previousTargetIDs, target.ID) {
+			continue
+		}
+
+		// pin the entities so unmanaged targets keep syncing the same
+		// entities even if the top level entities change
+		if len(target.Entities) == 0 {
+			target.Entities = current.Entities
+		}
+
+		targets = append(targets, target)
+	}
+	accessFederation.Targets = targets
+
+	return &accessFederation
+}
+
+// putStar writes the planned federation configuration of the source through
+// updateAccessFederation, so a change made concurrently, e.g. by a
+// missioncontrol_access_federation_target resource of another Terraform run,
+// is detected rather than overwritten.
+func (r *accessFederationStarResource) putStar(plan accessFederationStarResourceModel, accessFederation accessFederationRequestAPIModel, previousTargetIDs []string) ([]accessFederationResponseAPIModel, error) {
+	return updateAccessFederation(
+		r.ProviderData.Client,
+		plan.ID.ValueString(),
+		func(current *accessFederationGetResponseAPIModel) *accessFederationRequestAPIModel {
+			if plan.TargetManagement.ValueString() != targetManagementAdditive {
+				return &accessFederation
+			}
+
+			return mergeUnmanagedTargets(accessFederation, current, previousTargetIDs)
+		},
+	)
 }
 
 func (r *accessFederationStarResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan accessFederationStarResourceModel
@@ -281,26 +1003,27 @@ func (r *accessFederationStarResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	var accessFederation accessFederationRequestAPIModel
-	resp.Diagnostics.Append(plan.toAPIModel(ctx, &accessFederation)...)
-	if resp.Diagnostics.HasError() {
+	if err := plan.resolveNames(r.ProviderData.Client); err != nil {
+		utilfw.UnableToCreateResourceError(resp, err.Error())
 		return
 	}
 
-	var results []accessFederationResponseAPIModel
-	response, err := r.ProviderData.Client.R().
-		SetPathParam("id", plan.ID.ValueString()).
-		SetBody(accessFederation).
-		SetResult(&results).
-		Put(accessFederationEndpoint)
-
+	targets, err := resolveTargetURLs(r.ProviderData.Client, plan.Targets)
 	if err != nil {
 		utilfw.UnableToCreateResourceError(resp, err.Error())
 		return
 	}
+	plan.Targets = targets
 
-	if response.IsError() {
-		utilfw.UnableToCreateResourceError(resp, response.String())
+	var accessFederation accessFederationRequestAPIModel
+	resp.Diagnostics.Append(plan.toAPIModel(ctx, &accessFederation)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := r.putStar(plan, accessFederation, nil)
+	if err != nil {
+		utilfw.UnableToCreateResourceError(resp, err.Error())
 		return
 	}
 
@@ -311,7 +1034,14 @@ func (r *accessFederationStarResource) Create(ctx context.Context, req resource.
 		})
 	}
 
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed targets only after saving the state, as the federation
+	// may have been partially applied
+	resp.Diagnostics.Append(resultsDiags...)
 }
 
 func (r *accessFederationStarResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -340,6 +1070,26 @@ func (r *accessFederationStarResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	// imported resource has none of the optional attributes in state yet
+	if state.TargetManagement.IsNull() {
+		state.TargetManagement = types.StringValue(targetManagementExclusive)
+	}
+
+	if state.AllowPartialFailure.IsNull() {
+		state.AllowPartialFailure = types.BoolValue(false)
+	}
+
+	// targets added outside of this resource are not managed in additive mode
+	if state.TargetManagement.ValueString() == targetManagementAdditive {
+		managedTargetIDs := state.targetIDs()
+		accessFederation.Targets = lo.Filter(
+			accessFederation.Targets,
+			func(target accessFederationTargetAPIModel, _ int) bool {
+				return lo.Contains(managedTargetIDs, target.ID)
+			},
+		)
+	}
+
 	// Convert from the API data model to the Terraform data model
 	// and refresh any attribute values.
 	resp.Diagnostics.Append(state.fromAPIModel(ctx, &accessFederation)...)
@@ -351,6 +1101,11 @@ func (r *accessFederationStarResource) Read(ctx context.Context, req resource.Re
 }
 
 func (r *accessFederationStarResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan accessFederationStarResourceModel
@@ -360,26 +1115,34 @@ func (r *accessFederationStarResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	var accessFederation accessFederationRequestAPIModel
-	resp.Diagnostics.Append(plan.toAPIModel(ctx, &accessFederation)...)
+	var state accessFederationStarResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var results []accessFederationResponseAPIModel
-	response, err := r.ProviderData.Client.R().
-		SetPathParam("id", plan.ID.ValueString()).
-		SetBody(accessFederation).
-		SetResult(&results).
-		Put(accessFederationEndpoint)
+	if err := plan.resolveNames(r.ProviderData.Client); err != nil {
+		utilfw.UnableToUpdateResourceError(resp, err.Error())
+		return
+	}
 
+	targets, err := resolveTargetURLs(r.ProviderData.Client, plan.Targets)
 	if err != nil {
 		utilfw.UnableToUpdateResourceError(resp, err.Error())
 		return
 	}
+	plan.Targets = targets
 
-	if response.IsError() {
-		utilfw.UnableToUpdateResourceError(resp, response.String())
+	var accessFederation accessFederationRequestAPIModel
+	resp.Diagnostics.Append(plan.toAPIModel(ctx, &accessFederation)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, err := r.putStar(plan, accessFederation, state.targetIDs())
+	if err != nil {
+		utilfw.UnableToUpdateResourceError(resp, err.Error())
 		return
 	}
 
@@ -390,10 +1153,22 @@ func (r *accessFederationStarResource) Update(ctx context.Context, req resource.
 		})
 	}
 
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed targets only after saving the state, as the federation
+	// may have been partially applied
+	resp.Diagnostics.Append(resultsDiags...)
 }
 
 func (r *accessFederationStarResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	resp.Diagnostics.AddWarning(
@@ -0,0 +1,226 @@
+package missioncontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// newAccessFederationServer returns a server for the federation configuration
+// of JPD-1. changes is called on each GET, and returns the targets the
+// configuration has been changed to concurrently, if any.
+func newAccessFederationServer(t *testing.T, changes func(get int32) []accessFederationTargetAPIModel, puts *[]accessFederationRequestAPIModel) *httptest.Server {
+	t.Helper()
+
+	accessFederation := accessFederationGetResponseAPIModel{
+		Entities: []string{"USERS"},
+		Targets: []accessFederationTargetAPIModel{
+			{ID: "JPD-2", URL: "https://jpd-2/access", Entities: []string{"USERS"}},
+		},
+	}
+
+	var gets atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			if targets := changes(gets.Add(1)); targets != nil {
+				accessFederation.Targets = targets
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(accessFederation)
+		case http.MethodPut:
+			var request accessFederationRequestAPIModel
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			*puts = append(*puts, request)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte("[]"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPutAccessFederationLink_concurrentChange(t *testing.T) {
+	var puts []accessFederationRequestAPIModel
+	server := newAccessFederationServer(
+		t,
+		func(get int32) []accessFederationTargetAPIModel {
+			// another Terraform run adds JPD-3 between the first read and
+			// the write
+			if get == 2 {
+				return []accessFederationTargetAPIModel{
+					{ID: "JPD-2", URL: "https://jpd-2/access", Entities: []string{"USERS"}},
+					{ID: "JPD-3", URL: "https://jpd-3/access", Entities: []string{"USERS"}},
+				}
+			}
+			return nil
+		},
+		&puts,
+	)
+
+	_, err := putAccessFederationLink(
+		resty.New().SetBaseURL(server.URL),
+		"JPD-1",
+		accessFederationTargetAPIModel{ID: "JPD-4", URL: "https://jpd-4/access", Entities: []string{"USERS"}},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(puts) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(puts))
+	}
+
+	var targetIDs []string
+	for _, target := range puts[0].Targets {
+		targetIDs = append(targetIDs, target.ID)
+	}
+	if len(targetIDs) != 3 || targetIDs[0] != "JPD-2" || targetIDs[1] != "JPD-3" || targetIDs[2] != "JPD-4" {
+		t.Errorf("expected targets JPD-2, JPD-3, and JPD-4, got %v", targetIDs)
+	}
+}
+
+func TestDeleteAccessFederationLink_keepsChanging(t *testing.T) {
+	var puts []accessFederationRequestAPIModel
+	server := newAccessFederationServer(
+		t,
+		func(get int32) []accessFederationTargetAPIModel {
+			return []accessFederationTargetAPIModel{
+				{ID: "JPD-2", URL: "https://jpd-2/access", Entities: []string{"USERS"}},
+				{ID: "JPD-3", URL: "https://jpd-3/access", Entities: []string{"USERS"}, PermissionFilters: &accessFederationPermissionFiltersAPIModel{IncludePatterns: []string{fmt.Sprintf("permission-%d", get)}}},
+			}
+		},
+		&puts,
+	)
+
+	_, err := deleteAccessFederationLink(resty.New().SetBaseURL(server.URL), "JPD-1", "JPD-2")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	if len(puts) != 0 {
+		t.Errorf("expected no update, got %d", len(puts))
+	}
+}
+
+func TestAccessFederationStarPutStar_concurrentChange(t *testing.T) {
+	var puts []accessFederationRequestAPIModel
+	server := newAccessFederationServer(
+		t,
+		func(get int32) []accessFederationTargetAPIModel {
+			// a missioncontrol_access_federation_target resource of another
+			// Terraform run adds JPD-3 between the first read and the write
+			if get == 2 {
+				return []accessFederationTargetAPIModel{
+					{ID: "JPD-2", URL: "https://jpd-2/access", Entities: []string{"USERS"}},
+					{ID: "JPD-3", URL: "https://jpd-3/access", Entities: []string{"USERS"}},
+				}
+			}
+			return nil
+		},
+		&puts,
+	)
+
+	r := &accessFederationStarResource{
+		ProviderData: ProviderMetadata{
+			ProviderMetadata: util.ProviderMetadata{Client: resty.New().SetBaseURL(server.URL)},
+		},
+	}
+	plan := accessFederationStarResourceModel{
+		ID:               types.StringValue("JPD-1"),
+		TargetManagement: types.StringValue(targetManagementAdditive),
+	}
+
+	_, err := r.putStar(
+		plan,
+		accessFederationRequestAPIModel{
+			ID:       "JPD-1",
+			Entities: []string{"USERS"},
+			Targets: []accessFederationTargetAPIModel{
+				{ID: "JPD-4", URL: "https://jpd-4/access", Entities: []string{"USERS"}},
+			},
+		},
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(puts) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(puts))
+	}
+
+	var targetIDs []string
+	for _, target := range puts[0].Targets {
+		targetIDs = append(targetIDs, target.ID)
+	}
+	if len(targetIDs) != 3 || targetIDs[0] != "JPD-4" || targetIDs[1] != "JPD-2" || targetIDs[2] != "JPD-3" {
+		t.Errorf("expected targets JPD-4, JPD-2, and JPD-3, got %v", targetIDs)
+	}
+}
+
+func starTargetValue(id string, url types.String) attr.Value {
+	return types.ObjectValueMust(
+		targetAttributeTypes,
+		map[string]attr.Value{
+			"id":                 types.StringValue(id),
+			"name":               types.StringNull(),
+			"url":                url,
+			"entities":           types.SetNull(types.StringType),
+			"permission_filters": types.ObjectNull(permissionFilterAttributeTypes),
+		},
+	)
+}
+
+func TestPlanTargetURLs(t *testing.T) {
+	var jpdRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jpdRequests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"id": %q, "base_url": "https://%s/"}`, path.Base(r.URL.Path), strings.ToLower(path.Base(r.URL.Path)))
+	}))
+	t.Cleanup(server.Close)
+
+	targets := types.SetValueMust(targetsElmementType, []attr.Value{
+		starTargetValue("JPD-2", types.StringUnknown()),
+		starTargetValue("JPD-3", types.StringValue("https://jpd-3/access")),
+		starTargetValue("JPD-4", types.StringValue("https://other/access")),
+	})
+
+	planned, ds := planTargetURLs(context.Background(), resty.New().SetBaseURL(server.URL), targets)
+	if ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	if !planned.Equal(types.SetValueMust(targetsElmementType, []attr.Value{
+		starTargetValue("JPD-2", types.StringValue("https://jpd-2/access")),
+		starTargetValue("JPD-3", types.StringValue("https://jpd-3/access")),
+		starTargetValue("JPD-4", types.StringValue("https://other/access")),
+	})) {
+		t.Errorf("expected the URL of JPD-2 to be resolved, got %v", planned)
+	}
+
+	if ds.WarningsCount() != 1 || !strings.Contains(ds.Warnings()[0].Detail(), "JPD-4") {
+		t.Errorf("expected a warning for JPD-4, got %v", ds.Warnings())
+	}
+
+	// one request per target JPD
+	if jpdRequests.Load() != 3 {
+		t.Errorf("expected 3 JPD requests, got %d", jpdRequests.Load())
+	}
+}
@@ -2,6 +2,7 @@ package missioncontrol_test
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -21,7 +22,7 @@ func TestAccAccessFederationStar_full(t *testing.T) {
 	}
 
 	if skip, reason := skipTest(); skip {
-		t.Skipf(reason)
+		t.Skip(reason)
 	}
 
 	_, fqrn, resourceName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_star")
@@ -56,6 +57,7 @@ func TestAccAccessFederationStar_full(t *testing.T) {
 			{
 				id = "JPD-2"
 				url = "http://host.docker.internal:9082/access"
+				entities = ["USERS", "PERMISSIONS"]
 				permission_filters = {
 					include_patterns = ["foo"]
 				}
@@ -64,6 +66,45 @@ func TestAccAccessFederationStar_full(t *testing.T) {
 	}`
 	updatedConfig := util.ExecuteTemplate(resourceName, updatedTemp, testData)
 
+	permissionFiltersTemp := `
+	resource "missioncontrol_access_federation_star" "{{ .name }}" {
+		id = "JPD-1"
+		entities = ["USERS", "GROUPS", "PERMISSIONS"]
+		targets = [
+			{
+				id = "JPD-2"
+				url = "http://host.docker.internal:9082/access"
+				{{ .permissionFilters }}
+			},
+		]
+	}`
+
+	noPermissionFiltersConfig := util.ExecuteTemplate(resourceName, permissionFiltersTemp, map[string]string{
+		"name":              resourceName,
+		"permissionFilters": "",
+	})
+
+	emptyPermissionFiltersConfig := util.ExecuteTemplate(resourceName, permissionFiltersTemp, map[string]string{
+		"name":              resourceName,
+		"permissionFilters": "permission_filters = {}",
+	})
+
+	excludeOnlyPermissionFiltersConfig := util.ExecuteTemplate(resourceName, permissionFiltersTemp, map[string]string{
+		"name":              resourceName,
+		"permissionFilters": `permission_filters = { exclude_patterns = ["fizz"] }`,
+	})
+
+	derivedURLConfig := util.ExecuteTemplate(resourceName, `
+	resource "missioncontrol_access_federation_star" "{{ .name }}" {
+		id = "JPD-1"
+		entities = ["USERS", "GROUPS", "PERMISSIONS"]
+		targets = [
+			{
+				id = "JPD-2"
+			},
+		]
+	}`, testData)
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProviders(),
@@ -73,13 +114,17 @@ func TestAccAccessFederationStar_full(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr(fqrn, "id", "JPD-1"),
 					resource.TestCheckResourceAttr(fqrn, "entities.#", "4"),
+					resource.TestCheckResourceAttr(fqrn, "target_management", "exclusive"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "USERS"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "GROUPS"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "PERMISSIONS"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "TOKENS"),
+					resource.TestCheckResourceAttr(fqrn, "allow_partial_failure", "false"),
+					resource.TestCheckResourceAttrSet(fqrn, "last_operation_results.#"),
 					resource.TestCheckResourceAttr(fqrn, "targets.#", "1"),
 					resource.TestCheckResourceAttr(fqrn, "targets.0.id", "JPD-2"),
 					resource.TestCheckResourceAttr(fqrn, "targets.0.url", "http://host.docker.internal:9082/access"),
+					resource.TestCheckNoResourceAttr(fqrn, "targets.0.entities"),
 					resource.TestCheckResourceAttr(fqrn, "targets.0.permission_filters.include_patterns.#", "2"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "targets.0.permission_filters.include_patterns.*", "foo"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "targets.0.permission_filters.include_patterns.*", "bar"),
@@ -99,15 +144,180 @@ func TestAccAccessFederationStar_full(t *testing.T) {
 					resource.TestCheckResourceAttr(fqrn, "targets.#", "1"),
 					resource.TestCheckResourceAttr(fqrn, "targets.0.id", "JPD-2"),
 					resource.TestCheckResourceAttr(fqrn, "targets.0.url", "http://host.docker.internal:9082/access"),
+					resource.TestCheckResourceAttr(fqrn, "targets.0.entities.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "targets.0.entities.*", "USERS"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "targets.0.entities.*", "PERMISSIONS"),
 					resource.TestCheckResourceAttr(fqrn, "targets.0.permission_filters.include_patterns.#", "1"),
 					resource.TestCheckTypeSetElemAttr(fqrn, "targets.0.permission_filters.include_patterns.*", "foo"),
 					resource.TestCheckNoResourceAttr(fqrn, "targets.0.permission_filters.exclude_patterns"),
 				),
 			},
 			{
-				ResourceName:      fqrn,
-				ImportState:       true,
-				ImportStateVerify: true,
+				Config: noPermissionFiltersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "targets.#", "1"),
+					resource.TestCheckNoResourceAttr(fqrn, "targets.0.permission_filters"),
+				),
+			},
+			{
+				Config: emptyPermissionFiltersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "targets.#", "1"),
+					resource.TestCheckNoResourceAttr(fqrn, "targets.0.permission_filters.include_patterns"),
+					resource.TestCheckNoResourceAttr(fqrn, "targets.0.permission_filters.exclude_patterns"),
+				),
+			},
+			{
+				Config: excludeOnlyPermissionFiltersConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "targets.#", "1"),
+					resource.TestCheckNoResourceAttr(fqrn, "targets.0.permission_filters.include_patterns"),
+					resource.TestCheckResourceAttr(fqrn, "targets.0.permission_filters.exclude_patterns.#", "1"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "targets.0.permission_filters.exclude_patterns.*", "fizz"),
+				),
+			},
+			{
+				Config: derivedURLConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "targets.#", "1"),
+					resource.TestCheckResourceAttr(fqrn, "targets.0.id", "JPD-2"),
+					resource.TestMatchResourceAttr(fqrn, "targets.0.url", regexp.MustCompile(`^https?://.+/access$`)),
+				),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_operation_results"},
+			},
+		},
+	})
+}
+
+func TestAccAccessFederationStar_invalid_permission_filters(t *testing.T) {
+	_, _, resourceName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_star")
+
+	temp := `
+	resource "missioncontrol_access_federation_star" "{{ .name }}" {
+		id       = "JPD-1"
+		entities = {{ .entities }}
+		targets = [
+			{
+				id   = "JPD-2"
+				url  = "http://host.docker.internal:9082/access"
+				permission_filters = {
+					include_patterns = {{ .includePatterns }}
+					exclude_patterns = ["foo"]
+				}
+			}
+		]
+	}`
+
+	invalidPatternConfig := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"name":            resourceName,
+		"entities":        `["PERMISSIONS"]`,
+		"includePatterns": `["[a-"]`,
+	})
+
+	conflictingPatternConfig := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"name":            resourceName,
+		"entities":        `["PERMISSIONS"]`,
+		"includePatterns": `["foo"]`,
+	})
+
+	noPermissionsConfig := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"name":            resourceName,
+		"entities":        `["USERS", "GROUPS"]`,
+		"includePatterns": `["bar"]`,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      invalidPatternConfig,
+				ExpectError: regexp.MustCompile(".*value must be a valid regular expression.*"),
+			},
+			{
+				Config:      conflictingPatternConfig,
+				ExpectError: regexp.MustCompile(".*Conflicting permission filter patterns.*"),
+			},
+			{
+				Config:      noPermissionsConfig,
+				ExpectError: regexp.MustCompile(".*Permission filters have no effect.*"),
+			},
+		},
+	})
+}
+
+func TestAccAccessFederationStar_invalid_names(t *testing.T) {
+	_, _, resourceName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_star")
+
+	temp := `
+	resource "missioncontrol_access_federation_star" "{{ .name }}" {
+		{{ .source }}
+		entities = ["USERS"]
+		targets = [
+			{
+				{{ .target }}
+				url = "http://host.docker.internal:9082/access"
+			}
+		]
+	}`
+
+	bothSourceConfig := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"name":   resourceName,
+		"source": "id = \"JPD-1\"\n\t\tname = \"jpd-1\"",
+		"target": `id = "JPD-2"`,
+	})
+
+	noTargetConfig := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"name":   resourceName,
+		"source": `name = "jpd-1"`,
+		"target": "",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      bothSourceConfig,
+				ExpectError: regexp.MustCompile(".*Invalid Attribute Combination.*"),
+			},
+			{
+				Config:      noTargetConfig,
+				ExpectError: regexp.MustCompile(".*Invalid Attribute Combination.*"),
+			},
+		},
+	})
+}
+
+func TestAccAccessFederationStar_unsupported_entity_type(t *testing.T) {
+	_, _, resourceName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_star")
+
+	config := util.ExecuteTemplate(resourceName, `
+	resource "missioncontrol_access_federation_star" "{{ .name }}" {
+		id       = "JPD-1"
+		entities = ["USERS", "NOT_AN_ENTITY"]
+		targets = [
+			{
+				id  = "JPD-2"
+				url = "http://host.docker.internal:9082/access"
+			}
+		]
+	}`, map[string]string{
+		"name": resourceName,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(".*Unsupported entity type.*"),
 			},
 		},
 	})
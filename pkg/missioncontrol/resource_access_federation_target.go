@@ -0,0 +1,384 @@
+package missioncontrol
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jfrog/terraform-provider-shared/util"
+	utilfw "github.com/jfrog/terraform-provider-shared/util/fw"
+	validator_string "github.com/jfrog/terraform-provider-shared/validator/fw/string"
+	"github.com/samber/lo"
+)
+
+var _ resource.Resource = &accessFederationTargetResource{}
+var _ resource.ResourceWithModifyPlan = &accessFederationTargetResource{}
+var _ resource.ResourceWithConfigValidators = &accessFederationTargetResource{}
+
+type accessFederationTargetResource struct {
+	ProviderData ProviderMetadata
+	TypeName     string
+}
+
+func NewAccessFederationTargetResource() resource.Resource {
+	return &accessFederationTargetResource{
+		TypeName: "missioncontrol_access_federation_target",
+	}
+}
+
+func (r *accessFederationTargetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName
+}
+
+func (r *accessFederationTargetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Identifier in the form of `source_id:target_id`.",
+			},
+			"source_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID for the source Platform Deployment. Use [Get Access Federation Candidate API](https://jfrog.com/help/r/jfrog-rest-apis/get-access-federation-candidates) to get a list of ID.",
+			},
+			"target_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the targeted Platform Deployment",
+			},
+			"target_url": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					validator_string.IsURLHttpOrHttps(),
+					stringvalidator.RegexMatches(regexp.MustCompile(`^.+/access$`), "must end in '/access'"),
+				},
+				Description: "Target Platform deployment URL: http://<hostname>:<port>/access; for example: http://myplatformserver:8082/access.",
+			},
+			"entities": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						isEntityType(),
+					),
+				},
+				Description: "Entity types to sync to the target. For example: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`. The entity types are validated against the platform version during plan.",
+			},
+			"permission_filters": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"include_patterns": schema.SetAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(isPermissionFilterPattern()),
+						},
+						Description: "Regular expressions of permission names to be synchronized. A pattern can't be both included and excluded.",
+					},
+					"exclude_patterns": schema.SetAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(isPermissionFilterPattern()),
+						},
+						Description: "Regular expressions of permission names to be excluded from synchronization. A pattern can't be both included and excluded.",
+					},
+				},
+				Optional:    true,
+				Description: "When assigning entity types to targets, you can assign specific permissions to be synchronized using the `include_patterns`/`exclude_patterns` regular expressions.",
+			},
+			"allow_partial_failure":  allowPartialFailureSchemaAttribute,
+			"last_operation_results": operationResultsSchemaAttribute,
+		},
+		MarkdownDescription: "Provides a [JFrog Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation) resource to manage a single source to target relationship. " +
+			"Other targets of the same source are left untouched, so each target may be managed by different Terraform configuration.\n\n" +
+			"~>The source and target must have been configured properly for [Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation).\n\n" +
+			"~>Do not use together with `missioncontrol_access_federation_star` for the same source, as it manages all targets of the source.\n\n" +
+			"~>Within a Terraform run, resources with the same source are applied one at a time. Between concurrent Terraform runs, the configuration of the source is read again right before it is written, and the change is started over when another run modified it in the meantime. As Mission Control has no conditional update, a change made in between can still be lost, so avoid applying configurations with the same source at the same time.",
+	}
+}
+
+type accessFederationTargetResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	SourceID             types.String `tfsdk:"source_id"`
+	TargetID             types.String `tfsdk:"target_id"`
+	TargetURL            types.String `tfsdk:"target_url"`
+	Entities             types.Set    `tfsdk:"entities"`
+	PermissionFilters    types.Object `tfsdk:"permission_filters"`
+	AllowPartialFailure  types.Bool   `tfsdk:"allow_partial_failure"`
+	LastOperationResults types.List   `tfsdk:"last_operation_results"`
+}
+
+func (r *accessFederationTargetResourceModel) fromAPIModel(ctx context.Context, sourceEntities []string, apiModel *accessFederationTargetAPIModel) (ds diag.Diagnostics) {
+	r.ID = types.StringValue(fmt.Sprintf("%s:%s", r.SourceID.ValueString(), apiModel.ID))
+	r.TargetID = types.StringValue(apiModel.ID)
+	r.TargetURL = types.StringValue(apiModel.URL)
+
+	// target without its own entities syncs the source entities
+	entities := apiModel.Entities
+	if len(entities) == 0 {
+		entities = sourceEntities
+	}
+	entitiesSet, d := types.SetValueFrom(ctx, types.StringType, entities)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+	r.Entities = entitiesSet
+
+	permissionFilters, d := permissionFiltersFromAPIModel(ctx, apiModel.PermissionFilters, r.PermissionFilters)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+	r.PermissionFilters = permissionFilters
+
+	return
+}
+
+func (r accessFederationTargetResourceModel) toAPIModel(ctx context.Context, apiModel *accessFederationTargetAPIModel) diag.Diagnostics {
+	ds := diag.Diagnostics{}
+
+	var entities []string
+	ds.Append(r.Entities.ElementsAs(ctx, &entities, false)...)
+
+	permissionFilters, d := permissionFiltersToAPIModel(ctx, r.PermissionFilters)
+	ds.Append(d...)
+
+	*apiModel = accessFederationTargetAPIModel{
+		ID:                r.TargetID.ValueString(),
+		URL:               r.TargetURL.ValueString(),
+		Entities:          entities,
+		PermissionFilters: permissionFilters,
+	}
+
+	return ds
+}
+
+func (r *accessFederationTargetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		permissionFiltersConfigValidator{},
+	}
+}
+
+func (r *accessFederationTargetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *accessFederationTargetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan accessFederationTargetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.ProviderData.capabilities.RequireAccessFederation(ctx, plan.Entities, path.Root("entities"))...)
+}
+
+// upsert adds or replaces the target in the source federation configuration,
+// leaving all other targets of the source as is.
+func (r *accessFederationTargetResource) upsert(ctx context.Context, plan accessFederationTargetResourceModel) (results []accessFederationResponseAPIModel, ds diag.Diagnostics) {
+	var target accessFederationTargetAPIModel
+	ds.Append(plan.toAPIModel(ctx, &target)...)
+	if ds.HasError() {
+		return
+	}
+
+	results, err := putAccessFederationLink(r.ProviderData.Client, plan.SourceID.ValueString(), target)
+	if err != nil {
+		ds.AddError("Unable to update source Access Federation configuration", err.Error())
+		return
+	}
+
+	for _, result := range results {
+		tflog.Info(ctx, "Upsert result", map[string]interface{}{
+			"label":  result.Label,
+			"status": result.Status,
+		})
+	}
+
+	return
+}
+
+func (r *accessFederationTargetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan accessFederationTargetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, d := r.upsert(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(fmt.Sprintf("%s:%s", plan.SourceID.ValueString(), plan.TargetID.ValueString()))
+
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed target only after saving the state, as the federation
+	// may have been applied anyway
+	resp.Diagnostics.Append(resultsDiags...)
+}
+
+func (r *accessFederationTargetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	go util.SendUsageResourceRead(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state accessFederationTargetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessFederation, err := getAccessFederation(r.ProviderData.Client, state.SourceID.ValueString())
+	if err != nil {
+		utilfw.UnableToRefreshResourceError(resp, err.Error())
+		return
+	}
+
+	target, found := lo.Find(
+		accessFederation.Targets,
+		func(t accessFederationTargetAPIModel) bool {
+			return t.ID == state.TargetID.ValueString()
+		},
+	)
+	if !found {
+		resp.Diagnostics.AddWarning(
+			"Access Federation target not found",
+			fmt.Sprintf("Target %s is no longer federated from source %s. Removing from Terraform state.", state.TargetID.ValueString(), state.SourceID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// imported resource has none of the optional attributes in state yet
+	if state.AllowPartialFailure.IsNull() {
+		state.AllowPartialFailure = types.BoolValue(false)
+	}
+
+	// Convert from the API data model to the Terraform data model
+	// and refresh any attribute values.
+	resp.Diagnostics.Append(state.fromAPIModel(ctx, accessFederation.Entities, &target)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *accessFederationTargetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan accessFederationTargetResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, d := r.upsert(ctx, plan)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed target only after saving the state, as the federation
+	// may have been applied anyway
+	resp.Diagnostics.Append(resultsDiags...)
+}
+
+func (r *accessFederationTargetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state accessFederationTargetResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := deleteAccessFederationLink(r.ProviderData.Client, state.SourceID.ValueString(), state.TargetID.ValueString())
+	if err != nil {
+		utilfw.UnableToDeleteResourceError(resp, err.Error())
+		return
+	}
+
+	// If the logic reaches here, it implicitly succeeded and will remove
+	// the resource from state if there are no other errors.
+}
+
+// ImportState imports the resource into the Terraform state.
+func (r *accessFederationTargetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier in the form of: source_id:target_id",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("source_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("target_id"), parts[1])...)
+}
@@ -0,0 +1,97 @@
+package missioncontrol_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/testutil"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// To execute this test, you need setup second Artifactory instance with circle-of-trust.
+// Then set them as env vars before running the test
+func TestAccAccessFederationTarget_full(t *testing.T) {
+	var skipTest = func() (bool, string) {
+		if len(os.Getenv("ARTIFACTORY_URL_2")) > 0 {
+			return false, "Env var `ARTIFACTORY_URL_2` is set. Executing test."
+		}
+
+		return true, "Env var `ARTIFACTORY_URL_2` is not set. Skipping test."
+	}
+
+	if skip, reason := skipTest(); skip {
+		t.Skip(reason)
+	}
+
+	_, fqrn, resourceName := testutil.MkNames("test-access-federation", "missioncontrol_access_federation_target")
+
+	temp := `
+	resource "missioncontrol_access_federation_target" "{{ .name }}" {
+		source_id  = "JPD-1"
+		target_id  = "JPD-2"
+		target_url = "http://host.docker.internal:9082/access"
+		entities   = ["USERS", "GROUPS", "PERMISSIONS"]
+		permission_filters = {
+			include_patterns = ["foo", "bar"]
+		}
+	}`
+
+	testData := map[string]string{
+		"name": resourceName,
+	}
+
+	config := util.ExecuteTemplate(resourceName, temp, testData)
+
+	updatedTemp := `
+	resource "missioncontrol_access_federation_target" "{{ .name }}" {
+		source_id  = "JPD-1"
+		target_id  = "JPD-2"
+		target_url = "http://host.docker.internal:9082/access"
+		entities   = ["USERS", "GROUPS"]
+	}`
+	updatedConfig := util.ExecuteTemplate(resourceName, updatedTemp, testData)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", "JPD-1:JPD-2"),
+					resource.TestCheckResourceAttr(fqrn, "source_id", "JPD-1"),
+					resource.TestCheckResourceAttr(fqrn, "target_id", "JPD-2"),
+					resource.TestCheckResourceAttr(fqrn, "target_url", "http://host.docker.internal:9082/access"),
+					resource.TestCheckResourceAttr(fqrn, "entities.#", "3"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "USERS"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "GROUPS"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "PERMISSIONS"),
+					resource.TestCheckResourceAttr(fqrn, "permission_filters.include_patterns.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "permission_filters.include_patterns.*", "foo"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "permission_filters.include_patterns.*", "bar"),
+					resource.TestCheckNoResourceAttr(fqrn, "permission_filters.exclude_patterns"),
+					resource.TestCheckResourceAttr(fqrn, "allow_partial_failure", "false"),
+					resource.TestCheckResourceAttrSet(fqrn, "last_operation_results.#"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", "JPD-1:JPD-2"),
+					resource.TestCheckResourceAttr(fqrn, "entities.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "USERS"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "entities.*", "GROUPS"),
+					resource.TestCheckNoResourceAttr(fqrn, "permission_filters"),
+				),
+			},
+			{
+				ResourceName:            fqrn,
+				ImportState:             true,
+				ImportStateId:           "JPD-1:JPD-2",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_operation_results"},
+			},
+		},
+	})
+}
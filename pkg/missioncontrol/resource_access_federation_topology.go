@@ -0,0 +1,640 @@
+package missioncontrol
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jfrog/terraform-provider-shared/util"
+	utilfw "github.com/jfrog/terraform-provider-shared/util/fw"
+	validator_string "github.com/jfrog/terraform-provider-shared/validator/fw/string"
+	"github.com/samber/lo"
+)
+
+var _ resource.Resource = &accessFederationTopologyResource{}
+var _ resource.ResourceWithModifyPlan = &accessFederationTopologyResource{}
+var _ resource.ResourceWithConfigValidators = &accessFederationTopologyResource{}
+var _ resource.ResourceWithImportState = &accessFederationTopologyResource{}
+
+type accessFederationTopologyResource struct {
+	ProviderData ProviderMetadata
+	TypeName     string
+}
+
+func NewAccessFederationTopologyResource() resource.Resource {
+	return &accessFederationTopologyResource{
+		TypeName: "missioncontrol_access_federation_topology",
+	}
+}
+
+func (r *accessFederationTopologyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName
+}
+
+func (r *accessFederationTopologyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Comma separated IDs of the source Platform Deployments of the edges.",
+			},
+			"edges": schema.SetNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source_id": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							Description: "ID of the source Platform Deployment.",
+						},
+						"target_id": schema.StringAttribute{
+							Required: true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+							Description: "ID of the target Platform Deployment.",
+						},
+						"target_url": schema.StringAttribute{
+							Optional: true,
+							Validators: []validator.String{
+								validator_string.IsURLHttpOrHttps(),
+								stringvalidator.RegexMatches(regexp.MustCompile(`^.+/access$`), "must end in '/access'"),
+							},
+							MarkdownDescription: "Target Platform deployment URL: http://<hostname>:<port>/access; for example: http://myplatformserver:8082/access. When not set, the URL of the target registered in Mission Control is used.",
+						},
+						"entities": schema.SetAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+								setvalidator.ValueStringsAre(
+									isEntityType(),
+								),
+							},
+							Description: "Entity types to sync from the source to the target. For example: `USERS`, `GROUPS`, `PERMISSIONS`, `TOKENS`. The entity types are validated against the platform version during plan.",
+						},
+						"permission_filters": schema.SingleNestedAttribute{
+							Attributes: map[string]schema.Attribute{
+								"include_patterns": schema.SetAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Validators: []validator.Set{
+										setvalidator.ValueStringsAre(isPermissionFilterPattern()),
+									},
+									Description: "Regular expressions of permission names to be synchronized. A pattern can't be both included and excluded.",
+								},
+								"exclude_patterns": schema.SetAttribute{
+									ElementType: types.StringType,
+									Optional:    true,
+									Validators: []validator.Set{
+										setvalidator.ValueStringsAre(isPermissionFilterPattern()),
+									},
+									Description: "Regular expressions of permission names to be excluded from synchronization. A pattern can't be both included and excluded.",
+								},
+							},
+							Optional:    true,
+							Description: "When assigning entity types to targets, you can assign specific permissions to be synchronized using the `include_patterns`/`exclude_patterns` regular expressions.",
+						},
+					},
+				},
+				Required: true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				MarkdownDescription: "Directed federation edges from a source to a target Platform Deployment. The targets of each source are reconciled to exactly the edges from that source, so edges added outside of Terraform are removed.",
+			},
+			"allow_partial_failure":  allowPartialFailureSchemaAttribute,
+			"last_operation_results": operationResultsSchemaAttribute,
+		},
+		MarkdownDescription: "Provides a [JFrog Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation) resource to setup an arbitrary topology, e.g. a mesh between some Platform Deployments with stars fanning out to others, as a list of directed edges.\n\n" +
+			"~>The source and targets must have been configured properly for [Access Federation](https://jfrog.com/help/r/jfrog-platform-administration-documentation/access-federation).\n\n" +
+			"~>Do not use together with `missioncontrol_access_federation_star`, `missioncontrol_access_federation_mesh`, or `missioncontrol_access_federation_target` for the same source, as all targets of each source are managed by this resource.",
+	}
+}
+
+type accessFederationTopologyResourceModel struct {
+	ID                   types.String `tfsdk:"id"`
+	Edges                types.Set    `tfsdk:"edges"`
+	AllowPartialFailure  types.Bool   `tfsdk:"allow_partial_failure"`
+	LastOperationResults types.List   `tfsdk:"last_operation_results"`
+}
+
+var edgeAttributeTypes = map[string]attr.Type{
+	"source_id":          types.StringType,
+	"target_id":          types.StringType,
+	"target_url":         types.StringType,
+	"entities":           types.SetType{ElemType: types.StringType},
+	"permission_filters": types.ObjectType{AttrTypes: permissionFilterAttributeTypes},
+}
+
+var edgeElementType = types.ObjectType{
+	AttrTypes: edgeAttributeTypes,
+}
+
+// topologyEdge is a directed edge from the source to the target.
+type topologyEdge struct {
+	SourceID string
+	Target   accessFederationTargetAPIModel
+}
+
+func (e topologyEdge) key() string {
+	return e.SourceID + ":" + e.Target.ID
+}
+
+// sourceIDs returns the sorted IDs of the sources of the edges. An imported
+// resource has no edges in state yet so the ID is used instead.
+func (r accessFederationTopologyResourceModel) sourceIDs() []string {
+	var sourceIDs []string
+	if r.Edges.IsNull() || r.Edges.IsUnknown() {
+		sourceIDs = strings.Split(r.ID.ValueString(), ",")
+	} else {
+		sourceIDs = lo.Map(
+			r.Edges.Elements(),
+			func(elem attr.Value, _ int) string {
+				return elem.(types.Object).Attributes()["source_id"].(types.String).ValueString()
+			},
+		)
+	}
+
+	sourceIDs = lo.Uniq(sourceIDs)
+	slices.Sort(sourceIDs)
+
+	return sourceIDs
+}
+
+func (r accessFederationTopologyResourceModel) toAPIModel(ctx context.Context, edges *[]topologyEdge) (ds diag.Diagnostics) {
+	*edges = lo.Map(
+		r.Edges.Elements(),
+		func(elem attr.Value, _ int) topologyEdge {
+			attrs := elem.(types.Object).Attributes()
+
+			var entities []string
+			ds.Append(attrs["entities"].(types.Set).ElementsAs(ctx, &entities, false)...)
+
+			permissionFilters, d := permissionFiltersToAPIModel(ctx, attrs["permission_filters"].(types.Object))
+			ds.Append(d...)
+
+			return topologyEdge{
+				SourceID: attrs["source_id"].(types.String).ValueString(),
+				Target: accessFederationTargetAPIModel{
+					ID:                attrs["target_id"].(types.String).ValueString(),
+					URL:               attrs["target_url"].(types.String).ValueString(),
+					Entities:          entities,
+					PermissionFilters: permissionFilters,
+				},
+			}
+		},
+	)
+
+	return
+}
+
+func (r *accessFederationTopologyResourceModel) fromAPIModel(ctx context.Context, sourceIDs []string, accessFederations []accessFederationGetAllResponseAPIModel) (ds diag.Diagnostics) {
+	// target URL and permission filters from prior state/plan, keyed by edge
+	priorEdges := map[string]map[string]attr.Value{}
+	if !r.Edges.IsNull() && !r.Edges.IsUnknown() {
+		for _, elem := range r.Edges.Elements() {
+			attrs := elem.(types.Object).Attributes()
+			key := attrs["source_id"].(types.String).ValueString() + ":" + attrs["target_id"].(types.String).ValueString()
+			priorEdges[key] = attrs
+		}
+	}
+
+	var edges []attr.Value
+	for _, accessFederation := range accessFederations {
+		if !lo.Contains(sourceIDs, accessFederation.Source) {
+			continue
+		}
+
+		for _, target := range accessFederation.Targets {
+			edge := topologyEdge{SourceID: accessFederation.Source, Target: target.accessFederationTargetAPIModel}
+			prior, hasPrior := priorEdges[edge.key()]
+
+			// keep the URL unset when it was resolved from the registered JPD
+			targetURL := types.StringValue(target.URL)
+			if hasPrior && prior["target_url"].(types.String).IsNull() {
+				targetURL = types.StringNull()
+			}
+
+			// target without its own entities syncs the source entities
+			targetEntities := target.Entities
+			if len(targetEntities) == 0 {
+				targetEntities = accessFederation.Entities
+			}
+
+			entities, d := types.SetValueFrom(ctx, types.StringType, targetEntities)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			priorPermissionFilters := types.ObjectNull(permissionFilterAttributeTypes)
+			if hasPrior {
+				priorPermissionFilters = prior["permission_filters"].(types.Object)
+			}
+			permissionFilters, d := permissionFiltersFromAPIModel(ctx, target.PermissionFilters, priorPermissionFilters)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			e, d := types.ObjectValue(
+				edgeAttributeTypes,
+				map[string]attr.Value{
+					"source_id":          types.StringValue(edge.SourceID),
+					"target_id":          types.StringValue(edge.Target.ID),
+					"target_url":         targetURL,
+					"entities":           entities,
+					"permission_filters": permissionFilters,
+				},
+			)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			edges = append(edges, e)
+		}
+	}
+
+	edgesSet, d := types.SetValue(edgeElementType, edges)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+	r.Edges = edgesSet
+
+	r.ID = types.StringValue(strings.Join(sourceIDs, ","))
+
+	return
+}
+
+// topologyTargetsEqual returns true when the live targets of a source already
+// match the desired targets, so the source doesn't need to be updated.
+func topologyTargetsEqual(live, desired []accessFederationTargetAPIModel) bool {
+	if len(live) != len(desired) {
+		return false
+	}
+
+	sorted := func(values []string) []string {
+		values = slices.Clone(values)
+		slices.Sort(values)
+		return values
+	}
+
+	normalize := func(target accessFederationTargetAPIModel) string {
+		var includePatterns, excludePatterns []string
+		if target.PermissionFilters != nil {
+			includePatterns = sorted(target.PermissionFilters.IncludePatterns)
+			excludePatterns = sorted(target.PermissionFilters.ExcludePatterns)
+		}
+
+		return fmt.Sprintf(
+			"%s|%s|%s|%s|%s",
+			target.ID,
+			target.URL,
+			strings.Join(sorted(target.Entities), ","),
+			strings.Join(includePatterns, ","),
+			strings.Join(excludePatterns, ","),
+		)
+	}
+
+	liveTargets := lo.Map(live, func(t accessFederationTargetAPIModel, _ int) string { return normalize(t) })
+	desiredTargets := lo.Map(desired, func(t accessFederationTargetAPIModel, _ int) string { return normalize(t) })
+
+	return lo.Every(liveTargets, desiredTargets) && lo.Every(desiredTargets, liveTargets)
+}
+
+// reconcile updates each source whose live targets differ from the edges of
+// plan. Edges of sources no longer in plan, but in prior state, are deleted.
+func (r *accessFederationTopologyResource) reconcile(ctx context.Context, plan accessFederationTopologyResourceModel, state *accessFederationTopologyResourceModel) (results []accessFederationResponseAPIModel, ds diag.Diagnostics) {
+	var edges []topologyEdge
+	ds.Append(plan.toAPIModel(ctx, &edges)...)
+	if ds.HasError() {
+		return
+	}
+
+	// resolve URL of targets from the JPD registered in Mission Control
+	accessURLs := map[string]string{}
+	for i, edge := range edges {
+		if edge.Target.URL != "" {
+			continue
+		}
+
+		accessURL, ok := accessURLs[edge.Target.ID]
+		if !ok {
+			jpd, err := getJPD(r.ProviderData.Client, edge.Target.ID)
+			if err != nil {
+				ds.AddError("Unable to read Platform Deployment", fmt.Sprintf("%s: %s", edge.Target.ID, err))
+				return
+			}
+			accessURL = jpd.accessURL()
+			accessURLs[edge.Target.ID] = accessURL
+		}
+		edges[i].Target.URL = accessURL
+	}
+
+	desiredTargets := lo.GroupBy(edges, func(edge topologyEdge) string {
+		return edge.SourceID
+	})
+
+	for _, sourceID := range plan.sourceIDs() {
+		targets := lo.Map(desiredTargets[sourceID], func(edge topologyEdge, _ int) accessFederationTargetAPIModel {
+			return edge.Target
+		})
+
+		// every target has its own entities so the source entities are only
+		// the union of these
+		entities := lo.Uniq(lo.Flatten(lo.Map(targets, func(target accessFederationTargetAPIModel, _ int) []string {
+			return target.Entities
+		})))
+
+		// the live targets are read again right before writing, so a
+		// concurrent change of the source is detected rather than overwritten
+		res, err := updateAccessFederation(
+			r.ProviderData.Client,
+			sourceID,
+			func(current *accessFederationGetResponseAPIModel) *accessFederationRequestAPIModel {
+				liveTargets := lo.Map(current.Targets, func(target accessFederationTargetAPIModel, _ int) accessFederationTargetAPIModel {
+					// target without its own entities syncs the source entities
+					if len(target.Entities) == 0 {
+						target.Entities = current.Entities
+					}
+					return target
+				})
+
+				if topologyTargetsEqual(liveTargets, targets) {
+					return nil
+				}
+
+				tflog.Info(ctx, "Reconciling source targets", map[string]interface{}{
+					"source":  sourceID,
+					"targets": len(targets),
+				})
+
+				return &accessFederationRequestAPIModel{
+					ID:       sourceID,
+					Entities: entities,
+					Targets:  targets,
+				}
+			},
+		)
+		if err != nil {
+			// the other sources are still reconciled so every failure is
+			// reported at once
+			ds.AddError("Unable to update Access Federation configuration", fmt.Sprintf("%s: %s", sourceID, err))
+			continue
+		}
+		results = append(results, res...)
+	}
+
+	if state == nil || ds.HasError() {
+		return
+	}
+
+	// sources which are no longer in plan only lose the edges of this resource
+	var priorEdges []topologyEdge
+	ds.Append(state.toAPIModel(ctx, &priorEdges)...)
+	if ds.HasError() {
+		return
+	}
+
+	for _, edge := range priorEdges {
+		if _, ok := desiredTargets[edge.SourceID]; ok {
+			continue
+		}
+
+		res, err := deleteAccessFederationLink(r.ProviderData.Client, edge.SourceID, edge.Target.ID)
+		if err != nil {
+			ds.AddError("Unable to delete Access Federation link", err.Error())
+			continue
+		}
+		results = append(results, res...)
+	}
+
+	return
+}
+
+func (r *accessFederationTopologyResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		permissionFiltersConfigValidator{},
+	}
+}
+
+func (r *accessFederationTopologyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *accessFederationTopologyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan accessFederationTopologyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Edges.IsUnknown() {
+		return
+	}
+
+	// ID is known once all planned sources are
+	sourcesKnown := lo.EveryBy(plan.Edges.Elements(), func(elem attr.Value) bool {
+		return !elem.(types.Object).Attributes()["source_id"].IsUnknown()
+	})
+	if sourcesKnown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("id"), strings.Join(plan.sourceIDs(), ","))...)
+	}
+
+	var entities []string
+	for _, elem := range plan.Edges.Elements() {
+		attrs := elem.(types.Object).Attributes()
+
+		edgeEntities := attrs["entities"].(types.Set)
+		if !edgeEntities.IsUnknown() {
+			var e []string
+			resp.Diagnostics.Append(edgeEntities.ElementsAs(ctx, &e, false)...)
+			entities = append(entities, e...)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	entitiesSet, d := types.SetValueFrom(ctx, types.StringType, lo.Uniq(entities))
+	resp.Diagnostics.Append(d...)
+	resp.Diagnostics.Append(r.ProviderData.capabilities.RequireAccessFederation(ctx, entitiesSet, path.Root("edges"))...)
+}
+
+func (r *accessFederationTopologyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan accessFederationTopologyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, d := r.reconcile(ctx, plan, nil)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, result := range results {
+		tflog.Info(ctx, "Create result", map[string]interface{}{
+			"label":  result.Label,
+			"status": result.Status,
+		})
+	}
+
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+	plan.ID = types.StringValue(strings.Join(plan.sourceIDs(), ","))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed edges only after saving the state, as the topology may
+	// have been partially created
+	resp.Diagnostics.Append(resultsDiags...)
+}
+
+func (r *accessFederationTopologyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	go util.SendUsageResourceRead(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state accessFederationTopologyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	accessFederations, err := getAccessFederations(r.ProviderData.Client)
+	if err != nil {
+		utilfw.UnableToRefreshResourceError(resp, err.Error())
+		return
+	}
+
+	// imported resource has no allow partial failure in state yet
+	if state.AllowPartialFailure.IsNull() {
+		state.AllowPartialFailure = types.BoolValue(false)
+	}
+
+	// Convert from the API data model to the Terraform data model
+	// and refresh any attribute values.
+	resp.Diagnostics.Append(state.fromAPIModel(ctx, state.sourceIDs(), accessFederations)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *accessFederationTopologyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan accessFederationTopologyResourceModel
+	var state accessFederationTopologyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	results, d := r.reconcile(ctx, plan, &state)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, result := range results {
+		tflog.Info(ctx, "Update result", map[string]interface{}{
+			"label":  result.Label,
+			"status": result.Status,
+		})
+	}
+
+	lastOperationResults, resultsDiags := accessFederationOperationResults(results, plan.AllowPartialFailure.ValueBool())
+	plan.LastOperationResults = lastOperationResults
+	plan.ID = types.StringValue(strings.Join(plan.sourceIDs(), ","))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	// report failed edges only after saving the state, as the topology may
+	// have been partially updated
+	resp.Diagnostics.Append(resultsDiags...)
+}
+
+func (r *accessFederationTopologyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state accessFederationTopologyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var edges []topologyEdge
+	resp.Diagnostics.Append(state.toAPIModel(ctx, &edges)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, edge := range edges {
+		if _, err := deleteAccessFederationLink(r.ProviderData.Client, edge.SourceID, edge.Target.ID); err != nil {
+			utilfw.UnableToDeleteResourceError(resp, err.Error())
+			return
+		}
+	}
+
+	// If the logic reaches here, it implicitly succeeded and will remove
+	// the resource from state if there are no other errors.
+}
+
+// ImportState imports the resource into the Terraform state.
+func (r *accessFederationTopologyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	sourceIDs := lo.Compact(strings.Split(req.ID, ","))
+	if len(sourceIDs) == 0 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected at least one source JPD ID in the form of: jpd_id_1,jpd_id_2,...",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strings.Join(sourceIDs, ","))...)
+}
@@ -0,0 +1,146 @@
+package missioncontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+func TestAccessFederationTopologyFromAPIModel_sourceEntities(t *testing.T) {
+	ctx := context.Background()
+
+	model := accessFederationTopologyResourceModel{
+		Edges: types.SetNull(types.ObjectType{AttrTypes: edgeAttributeTypes}),
+	}
+
+	accessFederations := []accessFederationGetAllResponseAPIModel{
+		{
+			Source:   "JPD-1",
+			Entities: []string{"USERS", "GROUPS"},
+			Targets: []accessFederationTargetGetAllAPIModel{
+				// no entities of its own, syncs the source entities
+				meshTarget("JPD-2"),
+			},
+		},
+	}
+
+	if ds := model.fromAPIModel(ctx, []string{"JPD-1"}, accessFederations); ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	edges := model.Edges.Elements()
+	if len(edges) != 1 {
+		t.Fatalf("expected 1 edge, got %v", edges)
+	}
+
+	var entities []string
+	if ds := edges[0].(types.Object).Attributes()["entities"].(types.Set).ElementsAs(ctx, &entities, false); ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	if len(entities) != 2 {
+		t.Errorf("expected the source entities USERS and GROUPS, got %v", entities)
+	}
+}
+
+func topologyEdgeValue(sourceID, targetID string) attr.Value {
+	return types.ObjectValueMust(
+		edgeAttributeTypes,
+		map[string]attr.Value{
+			"source_id":          types.StringValue(sourceID),
+			"target_id":          types.StringValue(targetID),
+			"target_url":         types.StringValue("https://" + strings.ToLower(targetID) + "/access"),
+			"entities":           types.SetValueMust(types.StringType, []attr.Value{types.StringValue("USERS")}),
+			"permission_filters": types.ObjectNull(permissionFilterAttributeTypes),
+		},
+	)
+}
+
+func TestAccessFederationTopologyReconcile_concurrentChange(t *testing.T) {
+	var gets atomic.Int32
+	var puts []accessFederationRequestAPIModel
+	server := newAccessFederationServer(
+		t,
+		func(get int32) []accessFederationTargetAPIModel {
+			gets.Store(get)
+
+			// another Terraform run adds JPD-3 between the first read and
+			// the write
+			if get == 2 {
+				return []accessFederationTargetAPIModel{
+					{ID: "JPD-2", URL: "https://jpd-2/access", Entities: []string{"USERS"}},
+					{ID: "JPD-3", URL: "https://jpd-3/access", Entities: []string{"USERS"}},
+				}
+			}
+			return nil
+		},
+		&puts,
+	)
+
+	r := &accessFederationTopologyResource{
+		ProviderData: ProviderMetadata{
+			ProviderMetadata: util.ProviderMetadata{Client: resty.New().SetBaseURL(server.URL)},
+		},
+	}
+	plan := accessFederationTopologyResourceModel{
+		Edges: types.SetValueMust(edgeElementType, []attr.Value{
+			topologyEdgeValue("JPD-1", "JPD-2"),
+			topologyEdgeValue("JPD-1", "JPD-4"),
+		}),
+	}
+
+	if _, ds := r.reconcile(context.Background(), plan, nil); ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	// the update is started over once the concurrent change is detected
+	if gets.Load() != 4 {
+		t.Errorf("expected 4 reads, got %d", gets.Load())
+	}
+
+	if len(puts) != 1 {
+		t.Fatalf("expected 1 update, got %d", len(puts))
+	}
+
+	var targetIDs []string
+	for _, target := range puts[0].Targets {
+		targetIDs = append(targetIDs, target.ID)
+	}
+	if len(targetIDs) != 2 || !slices.Contains(targetIDs, "JPD-2") || !slices.Contains(targetIDs, "JPD-4") {
+		t.Errorf("expected targets JPD-2 and JPD-4, got %v", targetIDs)
+	}
+}
+
+func TestAccessFederationTopologyReconcile_allErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(server.Close)
+
+	r := &accessFederationTopologyResource{
+		ProviderData: ProviderMetadata{
+			ProviderMetadata: util.ProviderMetadata{Client: resty.New().SetBaseURL(server.URL)},
+		},
+	}
+	plan := accessFederationTopologyResourceModel{
+		Edges: types.SetValueMust(edgeElementType, []attr.Value{
+			topologyEdgeValue("JPD-1", "JPD-2"),
+			topologyEdgeValue("JPD-3", "JPD-4"),
+		}),
+	}
+
+	// a failed source doesn't stop the others from being reconciled
+	_, ds := r.reconcile(context.Background(), plan, nil)
+	if ds.ErrorsCount() != 2 {
+		t.Errorf("expected an error for each source, got %v", ds)
+	}
+}
@@ -0,0 +1,649 @@
+package missioncontrol
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jfrog/terraform-provider-shared/client"
+	"github.com/jfrog/terraform-provider-shared/util"
+	"github.com/samber/lo"
+)
+
+const (
+	accessRootCertificateEndpoint     = "access/api/v1/cert/root"
+	accessTrustedCertificatesEndpoint = "access/api/v1/cert/trusted"
+	accessTrustedCertificateEndpoint  = "access/api/v1/cert/trusted/{alias}"
+)
+
+var _ resource.Resource = &circleOfTrustResource{}
+var _ resource.ResourceWithModifyPlan = &circleOfTrustResource{}
+
+type circleOfTrustResource struct {
+	ProviderData ProviderMetadata
+	TypeName     string
+}
+
+func NewCircleOfTrustResource() resource.Resource {
+	return &circleOfTrustResource{
+		TypeName: "missioncontrol_circle_of_trust",
+	}
+}
+
+func (r *circleOfTrustResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName
+}
+
+func (r *circleOfTrustResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"members": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(2),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+					),
+				},
+				MarkdownDescription: "Platform Deployments which trust each other. Each member is either a JPD ID registered in Mission Control, or the Access URL of the platform, e.g. `http://myplatformserver:8082/access`. Must have at least 2 items.",
+			},
+			"member_access_tokens": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				MarkdownDescription: "Access tokens keyed by member, for members which don't accept the provider access token. " +
+					"The token must be allowed to manage the trusted certificates of the member.",
+			},
+			"root_certificate_fingerprints": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "SHA-256 fingerprint of the root certificate of each member, keyed by member.",
+			},
+			"missing_trusts": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"member": schema.StringAttribute{
+							Computed:    true,
+							Description: "Member whose current root certificate is not trusted.",
+						},
+						"trusted_by": schema.StringAttribute{
+							Computed:    true,
+							Description: "Member which doesn't trust the root certificate.",
+						},
+					},
+				},
+				Computed:            true,
+				MarkdownDescription: "Root certificates not trusted by another member, e.g. removed outside of Terraform or after the root certificate was rotated. A repair is planned when any trust is missing.",
+			},
+		},
+		MarkdownDescription: "Provides a resource to establish the [Circle of Trust](https://jfrog.com/help/r/jfrog-platform-administration-documentation/establish-a-circle-of-trust) between Platform Deployments, as required by Access Federation. " +
+			"The root certificate of each member is uploaded as trusted certificate to every other member using the Access REST API. " +
+			"When the root certificate of a member changes, the new certificate is uploaded on the next apply. Members which can't be reached when refreshing, e.g. being decommissioned, are reported as warnings and their trusts are not verified.",
+	}
+}
+
+type circleOfTrustResourceModel struct {
+	ID                          types.String `tfsdk:"id"`
+	Members                     types.Set    `tfsdk:"members"`
+	MemberAccessTokens          types.Map    `tfsdk:"member_access_tokens"`
+	RootCertificateFingerprints types.Map    `tfsdk:"root_certificate_fingerprints"`
+	MissingTrusts               types.List   `tfsdk:"missing_trusts"`
+}
+
+var missingTrustAttributeTypes = map[string]attr.Type{
+	"member":     types.StringType,
+	"trusted_by": types.StringType,
+}
+
+var missingTrustElementType = types.ObjectType{
+	AttrTypes: missingTrustAttributeTypes,
+}
+
+func (r *circleOfTrustResourceModel) fromAPIModel(ctx context.Context, state *circleOfTrustState) (ds diag.Diagnostics) {
+	fingerprints, d := types.MapValueFrom(ctx, types.StringType, state.fingerprints())
+	if d.HasError() {
+		ds.Append(d...)
+	}
+	r.RootCertificateFingerprints = fingerprints
+
+	missingTrusts := lo.Map(
+		state.missingTrusts(),
+		func(trust circleOfTrustLink, _ int) attr.Value {
+			t, d := types.ObjectValue(
+				missingTrustAttributeTypes,
+				map[string]attr.Value{
+					"member":     types.StringValue(trust.Member),
+					"trusted_by": types.StringValue(trust.TrustedBy),
+				},
+			)
+			if d.HasError() {
+				ds.Append(d...)
+			}
+
+			return t
+		},
+	)
+	missingTrustsList, d := types.ListValue(missingTrustElementType, missingTrusts)
+	if d.HasError() {
+		ds.Append(d...)
+	}
+	r.MissingTrusts = missingTrustsList
+
+	return
+}
+
+type trustedCertificateAPIModel struct {
+	Alias       string `json:"alias"`
+	Certificate string `json:"certificate"`
+}
+
+// circleOfTrustMember is a member of the circle of trust with a client for the
+// Access REST API of the platform.
+type circleOfTrustMember struct {
+	Name   string
+	Client *resty.Client
+
+	RootCertificate     string
+	TrustedCertificates map[string]string // PEM keyed by alias
+}
+
+// circleOfTrustLink is a member whose root certificate is trusted by another
+// member.
+type circleOfTrustLink struct {
+	Member    string
+	TrustedBy string
+}
+
+// circleOfTrustState is the live root and trusted certificates of each member.
+type circleOfTrustState struct {
+	Members []*circleOfTrustMember
+	// Unreachable are the members which couldn't be read
+	Unreachable []string
+}
+
+func (s circleOfTrustState) fingerprints() map[string]string {
+	return lo.SliceToMap(
+		s.Members,
+		func(member *circleOfTrustMember) (string, string) {
+			return member.Name, certificateFingerprint(member.RootCertificate)
+		},
+	)
+}
+
+// missingTrusts returns every member whose current root certificate is not
+// trusted by another member.
+func (s circleOfTrustState) missingTrusts() []circleOfTrustLink {
+	var missingTrusts []circleOfTrustLink
+	for _, member := range s.Members {
+		for _, trustedBy := range s.Members {
+			if member == trustedBy {
+				continue
+			}
+
+			trusted, ok := trustedBy.TrustedCertificates[trustedCertificateAlias(member.Name)]
+			if !ok || certificateFingerprint(trusted) != certificateFingerprint(member.RootCertificate) {
+				missingTrusts = append(missingTrusts, circleOfTrustLink{Member: member.Name, TrustedBy: trustedBy.Name})
+			}
+		}
+	}
+
+	return missingTrusts
+}
+
+var trustedCertificateAliasRegex = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// trustedCertificateAlias returns the alias of the root certificate of the
+// member when uploaded as trusted certificate to other members.
+func trustedCertificateAlias(member string) string {
+	name := strings.TrimPrefix(strings.TrimPrefix(member, "https://"), "http://")
+	return "missioncontrol-" + strings.Trim(trustedCertificateAliasRegex.ReplaceAllString(name, "-"), "-")
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of the DER encoded
+// certificate.
+func certificateFingerprint(certificate string) string {
+	data := []byte(strings.TrimSpace(certificate))
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// memberClient returns a client for the Access REST API of the member.
+func (r *circleOfTrustResource) memberClient(member string, accessTokens map[string]string) (*resty.Client, error) {
+	var platformURL string
+	if strings.HasPrefix(member, "http://") || strings.HasPrefix(member, "https://") {
+		platformURL = strings.TrimSuffix(strings.TrimSuffix(member, "/"), "/access")
+	} else {
+		jpd, err := getJPD(r.ProviderData.Client, member)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read Platform Deployment %s: %w", member, err)
+		}
+
+		platformURL = strings.TrimSuffix(jpd.accessURL(), "/access")
+	}
+
+	memberClient, err := client.Build(platformURL, productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if accessToken, ok := accessTokens[member]; ok {
+		return client.AddAuth(memberClient, "", accessToken)
+	}
+
+	memberClient, err = client.AddAuth(memberClient, "", r.ProviderData.Client.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	// the provider access token may expire during the apply too
+	if r.ProviderData.authenticator != nil {
+		r.ProviderData.authenticator.install(memberClient)
+	}
+
+	return memberClient, nil
+}
+
+// readMember fetches the root certificate and trusted certificates of the
+// member.
+func (r *circleOfTrustResource) readMember(name string, accessTokens map[string]string) (*circleOfTrustMember, error) {
+	memberClient, err := r.memberClient(name, accessTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := memberClient.R().
+		SetHeader("Accept", "text/plain").
+		Get(accessRootCertificateEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("unable to read root certificate of %s: %s", name, response.String())
+	}
+	rootCertificate := response.String()
+
+	var trustedCertificates []trustedCertificateAPIModel
+	response, err = memberClient.R().
+		SetResult(&trustedCertificates).
+		Get(accessTrustedCertificatesEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	if response.IsError() {
+		return nil, fmt.Errorf("unable to read trusted certificates of %s: %s", name, response.String())
+	}
+
+	return &circleOfTrustMember{
+		Name:            name,
+		Client:          memberClient,
+		RootCertificate: rootCertificate,
+		TrustedCertificates: lo.SliceToMap(
+			trustedCertificates,
+			func(c trustedCertificateAPIModel) (string, string) {
+				return c.Alias, c.Certificate
+			},
+		),
+	}, nil
+}
+
+// readState fetches the root and trusted certificates of every member. When
+// skipUnreachable is set, members which can't be read are reported as
+// warnings, as these may have been decommissioned, and left out of the state.
+func (r *circleOfTrustResource) readState(ctx context.Context, model circleOfTrustResourceModel, skipUnreachable bool) (*circleOfTrustState, diag.Diagnostics) {
+	ds := diag.Diagnostics{}
+
+	var members []string
+	ds.Append(model.Members.ElementsAs(ctx, &members, false)...)
+
+	accessTokens, d := model.accessTokens(ctx)
+	ds.Append(d...)
+	if ds.HasError() {
+		return nil, ds
+	}
+
+	slices.Sort(members)
+
+	state := circleOfTrustState{}
+	for _, name := range members {
+		member, err := r.readMember(name, accessTokens)
+		if err != nil && skipUnreachable {
+			ds.AddAttributeWarning(path.Root("members"), "Unable to read circle of trust member", fmt.Sprintf("%s. Trusts involving %s are not verified.", err, name))
+			state.Unreachable = append(state.Unreachable, name)
+			continue
+		}
+		if err != nil {
+			ds.AddAttributeError(path.Root("members"), "Unable to read circle of trust member", err.Error())
+			return nil, ds
+		}
+		state.Members = append(state.Members, member)
+	}
+
+	return &state, ds
+}
+
+func (r circleOfTrustResourceModel) accessTokens(ctx context.Context) (map[string]string, diag.Diagnostics) {
+	accessTokens := map[string]string{}
+	if r.MemberAccessTokens.IsNull() || r.MemberAccessTokens.IsUnknown() {
+		return accessTokens, nil
+	}
+
+	ds := r.MemberAccessTokens.ElementsAs(ctx, &accessTokens, false)
+	return accessTokens, ds
+}
+
+// trust uploads the current root certificate of each member to every other
+// member which doesn't trust it yet.
+func (r *circleOfTrustResource) trust(ctx context.Context, state *circleOfTrustState) (ds diag.Diagnostics) {
+	members := lo.SliceToMap(
+		state.Members,
+		func(member *circleOfTrustMember) (string, *circleOfTrustMember) {
+			return member.Name, member
+		},
+	)
+
+	for _, missingTrust := range state.missingTrusts() {
+		member := members[missingTrust.Member]
+		trustedBy := members[missingTrust.TrustedBy]
+		alias := trustedCertificateAlias(member.Name)
+
+		tflog.Info(ctx, "Uploading trusted certificate", map[string]interface{}{
+			"member":     member.Name,
+			"trusted_by": trustedBy.Name,
+			"alias":      alias,
+		})
+
+		// an outdated certificate, e.g. before the root certificate was
+		// rotated, is replaced
+		if _, ok := trustedBy.TrustedCertificates[alias]; ok {
+			if err := deleteTrustedCertificate(trustedBy.Client, alias); err != nil {
+				ds.AddError("Unable to delete trusted certificate", fmt.Sprintf("%s from %s: %s", alias, trustedBy.Name, err))
+				return
+			}
+		}
+
+		response, err := trustedBy.Client.R().
+			SetBody(trustedCertificateAPIModel{
+				Alias:       alias,
+				Certificate: member.RootCertificate,
+			}).
+			Post(accessTrustedCertificatesEndpoint)
+		if err != nil {
+			ds.AddError("Unable to upload trusted certificate", fmt.Sprintf("%s to %s: %s", alias, trustedBy.Name, err))
+			return
+		}
+		if response.IsError() {
+			ds.AddError("Unable to upload trusted certificate", fmt.Sprintf("%s to %s: %s", alias, trustedBy.Name, response.String()))
+			return
+		}
+
+		trustedBy.TrustedCertificates[alias] = member.RootCertificate
+	}
+
+	return
+}
+
+// untrust deletes the root certificates of trustedMembers from the trusted
+// certificates of each of the members. Members which can't be reached are
+// reported as warnings, as these may have been decommissioned.
+func (r *circleOfTrustResource) untrust(ctx context.Context, members []string, accessTokens map[string]string, trustedMembers []string) (ds diag.Diagnostics) {
+	for _, name := range members {
+		memberClient, err := r.memberClient(name, accessTokens)
+		if err != nil {
+			ds.AddWarning("Unable to delete trusted certificates", fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+
+		for _, trusted := range trustedMembers {
+			if trusted == name {
+				continue
+			}
+
+			alias := trustedCertificateAlias(trusted)
+			tflog.Info(ctx, "Deleting trusted certificate", map[string]interface{}{
+				"member": name,
+				"alias":  alias,
+			})
+
+			if err := deleteTrustedCertificate(memberClient, alias); err != nil {
+				ds.AddError("Unable to delete trusted certificate", fmt.Sprintf("%s from %s: %s", alias, name, err))
+				return
+			}
+		}
+	}
+
+	return
+}
+
+func deleteTrustedCertificate(c *resty.Client, alias string) error {
+	response, err := c.R().
+		SetPathParam("alias", alias).
+		Delete(accessTrustedCertificateEndpoint)
+	if err != nil {
+		return err
+	}
+
+	if response.IsError() && response.StatusCode() != http.StatusNotFound {
+		return fmt.Errorf("%s", response.String())
+	}
+
+	return nil
+}
+
+func (r *circleOfTrustResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *circleOfTrustResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// every member is expected to trust every other member after apply. When
+	// trusts are missing in state, this plans an update which repairs them.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("missing_trusts"), types.ListValueMust(missingTrustElementType, []attr.Value{}))...)
+}
+
+// apply uploads any missing trusted certificate between the planned members.
+func (r *circleOfTrustResource) apply(ctx context.Context, plan *circleOfTrustResourceModel) (ds diag.Diagnostics) {
+	state, d := r.readState(ctx, *plan, false)
+	ds.Append(d...)
+	if ds.HasError() {
+		return
+	}
+
+	ds.Append(r.trust(ctx, state)...)
+	if ds.HasError() {
+		return
+	}
+
+	ds.Append(plan.fromAPIModel(ctx, state)...)
+
+	memberNames := lo.Map(
+		state.Members,
+		func(member *circleOfTrustMember, _ int) string {
+			return member.Name
+		},
+	)
+	plan.ID = types.StringValue(strings.Join(memberNames, ","))
+
+	return
+}
+
+func (r *circleOfTrustResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan circleOfTrustResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *circleOfTrustResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	go util.SendUsageResourceRead(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state circleOfTrustResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	liveState, d := r.readState(ctx, state, true)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorFingerprints := map[string]string{}
+	resp.Diagnostics.Append(state.RootCertificateFingerprints.ElementsAs(ctx, &priorFingerprints, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(state.fromAPIModel(ctx, liveState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// unreachable members keep their last known fingerprint
+	if len(liveState.Unreachable) > 0 {
+		fingerprints := liveState.fingerprints()
+		for _, name := range liveState.Unreachable {
+			if fingerprint, ok := priorFingerprints[name]; ok {
+				fingerprints[name] = fingerprint
+			}
+		}
+
+		fingerprintsMap, d := types.MapValueFrom(ctx, types.StringType, fingerprints)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		state.RootCertificateFingerprints = fingerprintsMap
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *circleOfTrustResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan circleOfTrustResourceModel
+	var state circleOfTrustResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var newMembers []string
+	resp.Diagnostics.Append(plan.Members.ElementsAs(ctx, &newMembers, false)...)
+
+	var oldMembers []string
+	resp.Diagnostics.Append(state.Members.ElementsAs(ctx, &oldMembers, false)...)
+
+	accessTokens, d := state.accessTokens(ctx)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// removed members no longer trust the remaining members, and vice versa
+	_, removedMembers := lo.Difference(newMembers, oldMembers)
+	if len(removedMembers) > 0 {
+		remainingMembers := lo.Without(oldMembers, removedMembers...)
+
+		resp.Diagnostics.Append(r.untrust(ctx, removedMembers, accessTokens, remainingMembers)...)
+		resp.Diagnostics.Append(r.untrust(ctx, remainingMembers, accessTokens, removedMembers)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *circleOfTrustResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state circleOfTrustResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var members []string
+	resp.Diagnostics.Append(state.Members.ElementsAs(ctx, &members, false)...)
+
+	accessTokens, d := state.accessTokens(ctx)
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.untrust(ctx, members, accessTokens, members)...)
+
+	// If the logic reaches here, it implicitly succeeded and will remove
+	// the resource from state if there are no other errors.
+}
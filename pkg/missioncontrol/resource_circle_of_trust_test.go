@@ -0,0 +1,61 @@
+package missioncontrol_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/testutil"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// To execute this test, you need setup second Artifactory instance and an
+// admin access token for it. Then set them as env vars before running the test
+func TestAccCircleOfTrust_full(t *testing.T) {
+	var skipTest = func() (bool, string) {
+		if len(os.Getenv("ARTIFACTORY_UI_URL_2")) > 0 && len(os.Getenv("JFROG_ACCESS_TOKEN_2")) > 0 {
+			return false, "Env var `ARTIFACTORY_UI_URL_2` and `JFROG_ACCESS_TOKEN_2` are set. Executing test."
+		}
+
+		return true, "Env var `ARTIFACTORY_UI_URL_2` or `JFROG_ACCESS_TOKEN_2` is not set. Skipping test."
+	}
+
+	if skip, reason := skipTest(); skip {
+		t.Skip(reason)
+	}
+
+	_, fqrn, resourceName := testutil.MkNames("test-circle-of-trust", "missioncontrol_circle_of_trust")
+
+	temp := `
+	resource "missioncontrol_circle_of_trust" "{{ .name }}" {
+		members = ["JPD-1", "{{ .url2 }}/access"]
+		member_access_tokens = {
+			"{{ .url2 }}/access" = "{{ .token2 }}"
+		}
+	}`
+
+	testData := map[string]string{
+		"name":   resourceName,
+		"url2":   os.Getenv("ARTIFACTORY_UI_URL_2"),
+		"token2": os.Getenv("JFROG_ACCESS_TOKEN_2"),
+	}
+
+	config := util.ExecuteTemplate(resourceName, temp, testData)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "members.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "members.*", "JPD-1"),
+					resource.TestCheckResourceAttr(fqrn, "root_certificate_fingerprints.%", "2"),
+					resource.TestCheckResourceAttrSet(fqrn, "root_certificate_fingerprints.JPD-1"),
+					resource.TestCheckResourceAttr(fqrn, "missing_trusts.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,275 @@
+package missioncontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jfrog/terraform-provider-shared/util"
+	utilfw "github.com/jfrog/terraform-provider-shared/util/fw"
+)
+
+var _ resource.Resource = &coldStorageBindingResource{}
+var _ resource.ResourceWithModifyPlan = &coldStorageBindingResource{}
+var _ resource.ResourceWithImportState = &coldStorageBindingResource{}
+
+type coldStorageBindingResource struct {
+	ProviderData ProviderMetadata
+	TypeName     string
+}
+
+func NewColdStorageBindingResource() resource.Resource {
+	return &coldStorageBindingResource{
+		TypeName: "missioncontrol_cold_storage_binding",
+	}
+}
+
+func (r *coldStorageBindingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName
+}
+
+func (r *coldStorageBindingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the cold storage Platform Deployment, which holds the connection.",
+			},
+			"jpd_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the live Platform Deployment.",
+			},
+			"cold_storage_jpd_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the Cold Artifact Storage Platform Deployment to connect the live Platform Deployment to. Must be registered as cold storage, i.e. with `is_cold_storage` being `true`.",
+			},
+			"token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				MarkdownDescription: "Join key of the cold storage Platform Deployment, sent along with the connection. Mission Control never returns the join key, so the connection is otherwise updated without it, like a `missioncontrol_jpd` resource without `token`. Set it when Mission Control rejects the update for the missing join key.",
+			},
+		},
+		MarkdownDescription: "Provides a resource to connect a live [JFrog Platform Deployment](https://jfrog.com/help/r/jfrog-platform-administration-documentation/manage-platform-deployments) to its [Cold Artifact Storage](https://jfrog.com/help/r/jfrog-platform-administration-documentation/cold-artifact-storage) Platform Deployment. The connection is set with the `cold_storage_jpd` field of the cold storage Platform Deployment, as shown by its `missioncontrol_jpd` `cold_storage_jpd` attribute.",
+	}
+}
+
+type coldStorageBindingResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	JPDID            types.String `tfsdk:"jpd_id"`
+	ColdStorageJPDID types.String `tfsdk:"cold_storage_jpd_id"`
+	Token            types.String `tfsdk:"token"`
+}
+
+// setColdStorageJPD connects the cold storage Platform Deployment to the live
+// Platform Deployment jpdID, or disconnects it when jpdID is empty.
+func setColdStorageJPD(client *resty.Client, coldStorageJPDID, token, jpdID string) error {
+	return updateJPD(client, coldStorageJPDID, token, func(_ *jpdGetResponseAPIModel, request *jpdPostRequestAPIModel) {
+		isColdStorage := true
+		request.IsColdStorage = &isColdStorage
+		request.ColdStorageJPD = &jpdID
+	})
+}
+
+func (r *coldStorageBindingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *coldStorageBindingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// only check when the binding is to be created
+	if !req.State.Raw.IsNull() || r.ProviderData.Client == nil {
+		return
+	}
+
+	var plan coldStorageBindingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	checks := []struct {
+		attrPath    path.Path
+		id          types.String
+		coldStorage bool
+	}{
+		{path.Root("jpd_id"), plan.JPDID, false},
+		{path.Root("cold_storage_jpd_id"), plan.ColdStorageJPDID, true},
+	}
+
+	for _, check := range checks {
+		if check.id.IsUnknown() {
+			continue
+		}
+
+		jpd, err := getJPD(r.ProviderData.Client, check.id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeWarning(
+				check.attrPath,
+				"Unable to validate Platform Deployment",
+				fmt.Sprintf("Platform Deployment %s can't be read before apply: %s", check.id.ValueString(), err),
+			)
+			continue
+		}
+
+		if jpd.IsColdStorage != check.coldStorage {
+			detail := fmt.Sprintf("Platform Deployment %s is not registered as cold storage.", check.id.ValueString())
+			if !check.coldStorage {
+				detail = fmt.Sprintf("Platform Deployment %s is registered as cold storage, and can't be connected to another cold storage.", check.id.ValueString())
+			}
+			resp.Diagnostics.AddAttributeError(check.attrPath, "Invalid cold storage binding", detail)
+			continue
+		}
+
+		// a cold storage holds a single connection, which would be replaced
+		if check.coldStorage && jpd.ColdStorageJPD != "" && jpd.ColdStorageJPD != plan.JPDID.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				check.attrPath,
+				"Invalid cold storage binding",
+				fmt.Sprintf("Platform Deployment %s is already connected to Platform Deployment %s.", check.id.ValueString(), jpd.ColdStorageJPD),
+			)
+		}
+	}
+}
+
+func (r *coldStorageBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan coldStorageBindingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setColdStorageJPD(r.ProviderData.Client, plan.ColdStorageJPDID.ValueString(), plan.Token.ValueString(), plan.JPDID.ValueString())
+	if err != nil {
+		utilfw.UnableToCreateResourceError(resp, err.Error())
+		return
+	}
+
+	plan.ID = plan.ColdStorageJPDID
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *coldStorageBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	go util.SendUsageResourceRead(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state coldStorageBindingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jpd, err := getJPD(r.ProviderData.Client, state.ID.ValueString())
+	if err != nil && !errors.Is(err, errJPDNotFound) {
+		utilfw.UnableToRefreshResourceError(resp, err.Error())
+		return
+	}
+
+	if err != nil || !jpd.IsColdStorage || jpd.ColdStorageJPD == "" {
+		resp.Diagnostics.AddWarning(
+			"Cold storage binding not found",
+			fmt.Sprintf("Cold storage Platform Deployment %s is no longer connected to a Platform Deployment. Removing from Terraform state.", state.ID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ColdStorageJPDID = types.StringValue(jpd.ID)
+	state.JPDID = types.StringValue(jpd.ColdStorageJPD)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *coldStorageBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	// only `token` can change without replacement, and it is not stored by
+	// Mission Control
+	var plan coldStorageBindingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *coldStorageBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state coldStorageBindingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := setColdStorageJPD(r.ProviderData.Client, state.ID.ValueString(), state.Token.ValueString(), "")
+	if err != nil && !errors.Is(err, errJPDNotFound) {
+		utilfw.UnableToDeleteResourceError(resp, err.Error())
+		return
+	}
+
+	// If the logic reaches here, it implicitly succeeded and will remove
+	// the resource from state if there are no other errors.
+}
+
+// ImportState imports the resource into the Terraform state. The import
+// identifier is the ID of the cold storage Platform Deployment.
+func (r *coldStorageBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,57 @@
+package missioncontrol
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+func TestSetColdStorageJPD(t *testing.T) {
+	var puts []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/mc/api/v1/jpds/JPD-2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id": "JPD-2", "name": "cold", "url": "https://cold/", "tags": ["archive"], "is_cold_storage": true}`))
+		case http.MethodPut:
+			var request map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			puts = append(puts, request)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := resty.New().SetBaseURL(server.URL)
+
+	if err := setColdStorageJPD(client, "JPD-2", "", "JPD-1"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := setColdStorageJPD(client, "JPD-2", "", ""); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(puts) != 2 {
+		t.Fatalf("expected 2 updates, got %d", len(puts))
+	}
+
+	// the cold storage JPD is written back as is, apart from its connection
+	for i, expected := range []string{"JPD-1", ""} {
+		if puts[i]["name"] != "cold" || puts[i]["is_cold_storage"] != true || puts[i]["cold_storage_jpd"] != expected {
+			t.Errorf("expected cold storage JPD connected to %q, got %v", expected, puts[i])
+		}
+		if _, ok := puts[i]["token"]; ok {
+			t.Errorf("expected no join key, got %v", puts[i])
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package missioncontrol_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jfrog/terraform-provider-shared/testutil"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// Requires a Cold Artifact Storage instance registered in Mission Control, with its ID in env var `MISSIONCONTROL_COLD_STORAGE_JPD_ID`.
+// To make tests work runs ./scripts/run-artifactory-2.sh which will export env var `ARTIFACTORY_URL_2`
+func TestAccColdStorageBinding_full(t *testing.T) {
+	coldStorageJPDID := os.Getenv("MISSIONCONTROL_COLD_STORAGE_JPD_ID")
+	if coldStorageJPDID == "" || len(os.Getenv("ARTIFACTORY_URL_2")) == 0 || len(os.Getenv("ARTIFACTORY_JOIN_KEY")) == 0 {
+		t.Skipf("Env var `MISSIONCONTROL_COLD_STORAGE_JPD_ID`, `ARTIFACTORY_URL_2`, or `ARTIFACTORY_JOIN_KEY` are not set. Skipping test.")
+	}
+
+	_, jpdFqrn, jpdName := testutil.MkNames("test-jpd", "missioncontrol_jpd")
+	_, fqrn, resourceName := testutil.MkNames("test-cold-storage-binding", "missioncontrol_cold_storage_binding")
+
+	temp := `
+	resource "missioncontrol_jpd" "{{ .jpdName }}" {
+		name = "{{ .jpdName }}"
+		url  = "http://host.docker.internal:9082/"
+		token  = "{{ .token }}"
+		deletion_protection = false
+
+		location = {
+			city_name = "San Francisco"
+			country_code = "US"
+			latitude = 37.7749
+			longitude = 122.4194
+		}
+	}
+
+	resource "missioncontrol_cold_storage_binding" "{{ .name }}" {
+		jpd_id              = missioncontrol_jpd.{{ .jpdName }}.id
+		cold_storage_jpd_id = "{{ .coldStorageJPDID }}"
+	}`
+
+	config := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"jpdName":          jpdName,
+		"name":             resourceName,
+		"token":            os.Getenv("ARTIFACTORY_JOIN_KEY"),
+		"coldStorageJPDID": coldStorageJPDID,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "id", coldStorageJPDID),
+					resource.TestCheckResourceAttrPair(fqrn, "jpd_id", jpdFqrn, "id"),
+					resource.TestCheckResourceAttr(fqrn, "cold_storage_jpd_id", coldStorageJPDID),
+				),
+			},
+			{
+				ResourceName:      fqrn,
+				ImportState:       true,
+				ImportStateId:     coldStorageJPDID,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// Requires a Cold Artifact Storage instance registered in Mission Control, with its ID in env var `MISSIONCONTROL_COLD_STORAGE_JPD_ID`
+func TestAccColdStorageBinding_cold_storage_as_live(t *testing.T) {
+	coldStorageJPDID := os.Getenv("MISSIONCONTROL_COLD_STORAGE_JPD_ID")
+	if coldStorageJPDID == "" {
+		t.Skipf("Env var `MISSIONCONTROL_COLD_STORAGE_JPD_ID` is not set. Skipping test.")
+	}
+
+	_, _, resourceName := testutil.MkNames("test-cold-storage-binding", "missioncontrol_cold_storage_binding")
+
+	temp := `
+	resource "missioncontrol_cold_storage_binding" "{{ .name }}" {
+		jpd_id              = "{{ .coldStorageJPDID }}"
+		cold_storage_jpd_id = "{{ .coldStorageJPDID }}"
+	}`
+
+	config := util.ExecuteTemplate(resourceName, temp, map[string]string{
+		"name":             resourceName,
+		"coldStorageJPDID": coldStorageJPDID,
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(".*is registered as cold storage.*"),
+			},
+		},
+	})
+}
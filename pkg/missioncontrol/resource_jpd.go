@@ -2,16 +2,25 @@ package missioncontrol
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"regexp"
+	"strings"
+	"sync"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/jfrog/terraform-provider-shared/util"
 	utilfw "github.com/jfrog/terraform-provider-shared/util/fw"
 	validator_string "github.com/jfrog/terraform-provider-shared/validator/fw/string"
@@ -24,9 +33,10 @@ const (
 )
 
 var _ resource.Resource = &jpdResource{}
+var _ resource.ResourceWithModifyPlan = &jpdResource{}
 
 type jpdResource struct {
-	ProviderData util.ProviderMetadata
+	ProviderData ProviderMetadata
 	TypeName     string
 }
 
@@ -119,6 +129,36 @@ func (r *jpdResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Optional:    true,
 				Description: "Add labels to be applied for filtering Platform Deployments according to categories for example, location, dedicated centers - dev, testing, production",
 			},
+			"ignore_external_tags": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "When set to `true`, tags added outside of this resource, e.g. by `missioncontrol_jpd_tags` resource, are kept on update and are not reported as drift in `tags`. They are still included in `tags_all`. Tags removed from the provider `default_tags` are removed from the Platform Deployment, as long as they were applied by this resource. Default to `false`.",
+			},
+			"deletion_protection": deletionProtectionSchemaAttribute,
+			"decommission": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"detach_licenses": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Detach the licenses of the Platform Deployment back to the license buckets holding them. Default to `false`.\n\n!>Not supported yet: Mission Control has no documented API to detach licenses, so setting it to `true` is an error. Detach the licenses in Mission Control before destroying the Platform Deployment.",
+					},
+					"remove_from_federations": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(true),
+						MarkdownDescription: "Remove the Platform Deployment from the targets of every Access Federation source, and remove its own targets. Default to `true`.",
+					},
+				},
+				Optional:            true,
+				MarkdownDescription: "When set, the Platform Deployment is decommissioned on destroy before it is deleted from Mission Control. The result of each step is reported as a warning, and the Platform Deployment is not deleted when a step fails.",
+			},
+			"tags_all": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "All tags applied to the Platform Deployment, including `default_tags` from the provider configuration.",
+			},
 			"base_url": schema.StringAttribute{
 				Computed: true,
 			},
@@ -181,10 +221,12 @@ func (r *jpdResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 				Computed: true,
 			},
 			"is_cold_storage": schema.BoolAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "Whether the Platform Deployment is a Cold Artifact Storage instance.",
 			},
 			"cold_storage_jpd": schema.StringAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "ID of the Platform Deployment connected to this cold storage. Use `missioncontrol_cold_storage_binding` resource to connect a live Platform Deployment to its cold storage.",
 			},
 		},
 		MarkdownDescription: "Provides a [JFrog Platform Deployment](https://jfrog.com/help/r/jfrog-platform-administration-documentation/manage-platform-deployments) resource to manage JPD.",
@@ -192,21 +234,25 @@ func (r *jpdResource) Schema(ctx context.Context, req resource.SchemaRequest, re
 }
 
 type jpdResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	URL            types.String `tfsdk:"url"`
-	BaseURL        types.String `tfsdk:"base_url"`
-	Token          types.String `tfsdk:"token"`
-	Username       types.String `tfsdk:"username"`
-	Password       types.String `tfsdk:"password"`
-	Location       types.Object `tfsdk:"location"`
-	Services       types.Set    `tfsdk:"services"`
-	Licenses       types.Set    `tfsdk:"licenses"`
-	Tags           types.Set    `tfsdk:"tags"`
-	Local          types.Bool   `tfsdk:"local"`
-	Status         types.Object `tfsdk:"status"`
-	IsColdStorage  types.Bool   `tfsdk:"is_cold_storage"`
-	ColdStorageJPD types.String `tfsdk:"cold_storage_jpd"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	URL                types.String `tfsdk:"url"`
+	BaseURL            types.String `tfsdk:"base_url"`
+	Token              types.String `tfsdk:"token"`
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	Location           types.Object `tfsdk:"location"`
+	Services           types.Set    `tfsdk:"services"`
+	Licenses           types.Set    `tfsdk:"licenses"`
+	Tags               types.Set    `tfsdk:"tags"`
+	IgnoreExternalTags types.Bool   `tfsdk:"ignore_external_tags"`
+	TagsAll            types.Set    `tfsdk:"tags_all"`
+	Decommission       types.Object `tfsdk:"decommission"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	Local              types.Bool   `tfsdk:"local"`
+	Status             types.Object `tfsdk:"status"`
+	IsColdStorage      types.Bool   `tfsdk:"is_cold_storage"`
+	ColdStorageJPD     types.String `tfsdk:"cold_storage_jpd"`
 }
 
 var licenseAttrTypes = map[string]attr.Type{
@@ -234,7 +280,7 @@ var serviceElemType = types.ObjectType{
 	AttrTypes: serviceAttrTypes,
 }
 
-func (r *jpdResourceModel) fromAPIModel(ctx context.Context, apiModel *jpdGetResponseAPIModel) (ds diag.Diagnostics) {
+func (r *jpdResourceModel) fromAPIModel(ctx context.Context, apiModel *jpdGetResponseAPIModel, defaultTags []string) (ds diag.Diagnostics) {
 	r.ID = types.StringValue(apiModel.ID)
 	r.Name = types.StringValue(apiModel.Name)
 	r.URL = types.StringValue(apiModel.URL)
@@ -341,11 +387,36 @@ func (r *jpdResourceModel) fromAPIModel(ctx context.Context, apiModel *jpdGetRes
 	}
 	r.Status = status
 
-	tags, d := types.SetValueFrom(ctx, types.StringType, apiModel.Tags)
+	var priorTags []string
+	if !r.Tags.IsUnknown() {
+		ds.Append(r.Tags.ElementsAs(ctx, &priorTags, false)...)
+	}
+
+	// default tags are only kept in `tags` if they were also set on the
+	// resource itself, so overlapping tags don't cause plan diffs. Neither are
+	// external tags when these are ignored.
+	tags := lo.Filter(
+		apiModel.Tags,
+		func(tag string, _ int) bool {
+			if r.IgnoreExternalTags.ValueBool() {
+				return lo.Contains(priorTags, tag)
+			}
+			return !lo.Contains(defaultTags, tag) || lo.Contains(priorTags, tag)
+		},
+	)
+	if len(tags) > 0 || !r.Tags.IsNull() {
+		tagsSet, d := types.SetValueFrom(ctx, types.StringType, tags)
+		if d.HasError() {
+			ds.Append(d...)
+		}
+		r.Tags = tagsSet
+	}
+
+	tagsAll, d := types.SetValueFrom(ctx, types.StringType, lo.Uniq(apiModel.Tags))
 	if d.HasError() {
 		ds.Append(d...)
 	}
-	r.Tags = tags
+	r.TagsAll = tagsAll
 	r.Local = types.BoolValue(apiModel.Local)
 	r.IsColdStorage = types.BoolValue(apiModel.IsColdStorage)
 
@@ -357,11 +428,69 @@ func (r *jpdResourceModel) fromAPIModel(ctx context.Context, apiModel *jpdGetRes
 	return
 }
 
-func (r jpdResourceModel) toAPIModel(ctx context.Context, apiModel *jpdPostRequestAPIModel, artifactoryVersion string) diag.Diagnostics {
+// jpdDefaultTagsPrivateKey is the private state key holding the default tags
+// applied to the JPD on last create or update.
+const jpdDefaultTagsPrivateKey = "default_tags"
+
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// appliedDefaultTags returns the default tags together with the ones applied on
+// last create or update, so default tags since removed from the provider
+// configuration are not mistaken for external tags, and are removed.
+func appliedDefaultTags(ctx context.Context, private privateState, defaultTags []string) ([]string, diag.Diagnostics) {
+	value, ds := private.GetKey(ctx, jpdDefaultTagsPrivateKey)
+	if ds.HasError() || value == nil {
+		return defaultTags, ds
+	}
+
+	var priorDefaultTags []string
+	if err := json.Unmarshal(value, &priorDefaultTags); err != nil {
+		ds.AddError("Unable to read applied default tags", err.Error())
+		return defaultTags, ds
+	}
+
+	return lo.Union(defaultTags, priorDefaultTags), ds
+}
+
+// setAppliedDefaultTags records the default tags applied to the JPD.
+func setAppliedDefaultTags(ctx context.Context, private privateState, defaultTags []string) diag.Diagnostics {
+	value, err := json.Marshal(defaultTags)
+	if err != nil {
+		return diag.Diagnostics{diag.NewErrorDiagnostic("Unable to record applied default tags", err.Error())}
+	}
+
+	return private.SetKey(ctx, jpdDefaultTagsPrivateKey, value)
+}
+
+// externalTags returns the tags of the JPD which are neither in the prior
+// `tags` nor default tags, i.e. tags added outside of this resource.
+func (r jpdResourceModel) externalTags(ctx context.Context, tags []string, defaultTags []string) ([]string, diag.Diagnostics) {
+	var priorTags []string
+	ds := r.Tags.ElementsAs(ctx, &priorTags, false)
+
+	return lo.Filter(
+		tags,
+		func(tag string, _ int) bool {
+			return !lo.Contains(priorTags, tag) && !lo.Contains(defaultTags, tag)
+		},
+	), ds
+}
+
+// toAPIModel converts the resource to the API model. The join key, or the
+// credentials of legacy 6.x instances, are sent as configured: the platform
+// version is only checked during plan, so a version which can't be read never
+// changes the request.
+func (r jpdResourceModel) toAPIModel(ctx context.Context, apiModel *jpdPostRequestAPIModel, defaultTags []string) diag.Diagnostics {
 	ds := diag.Diagnostics{}
 
 	var tags []string
 	ds.Append(r.Tags.ElementsAs(ctx, &tags, false)...)
+	if len(defaultTags) > 0 {
+		tags = lo.Union(tags, defaultTags)
+	}
 
 	locationAttrs := r.Location.Attributes()
 	*apiModel = jpdPostRequestAPIModel{
@@ -373,19 +502,10 @@ func (r jpdResourceModel) toAPIModel(ctx context.Context, apiModel *jpdPostReque
 			Latitude:    locationAttrs["latitude"].(types.Float64).ValueFloat64(),
 			Longitude:   locationAttrs["longitude"].(types.Float64).ValueFloat64(),
 		},
-		Tags: tags,
-	}
-
-	notLegacy, err := util.CheckVersion(artifactoryVersion, "7.0.0")
-	if err != nil {
-		ds.AddError("faild to check version", err.Error())
-	}
-
-	if notLegacy {
-		apiModel.Token = r.Token.ValueString()
-	} else {
-		apiModel.Username = r.Username.ValueString()
-		apiModel.Password = r.Password.ValueString()
+		Token:    r.Token.ValueString(),
+		Username: r.Username.ValueString(),
+		Password: r.Password.ValueString(),
+		Tags:     tags,
 	}
 
 	return ds
@@ -399,6 +519,10 @@ type jpdPostRequestAPIModel struct {
 	Password string              `json:"password,omitempty"` // legacy (Artifactory 6.x only)
 	Location jpdLocationAPIModel `json:"location"`
 	Tags     []string            `json:"tags"`
+	// only sent by missioncontrol_cold_storage_binding, so missioncontrol_jpd
+	// leaves the cold storage connection as is
+	IsColdStorage  *bool   `json:"is_cold_storage,omitempty"`
+	ColdStorageJPD *string `json:"cold_storage_jpd,omitempty"`
 }
 
 type jpdLocationAPIModel struct {
@@ -423,6 +547,128 @@ type jpdGetResponseAPIModel struct {
 	ColdStorageJPD string               `json:"cold_storage_jpd"`
 }
 
+// accessURL returns the URL of the Access service of the JPD, as used by
+// Access Federation.
+func (m jpdGetResponseAPIModel) accessURL() string {
+	baseURL := m.BaseURL
+	if baseURL == "" {
+		baseURL = m.URL
+	}
+
+	return strings.TrimSuffix(baseURL, "/") + "/access"
+}
+
+// jpdLocks serializes read-modify-write of a Platform Deployment, e.g. its
+// tags, keyed by JPD ID.
+var jpdLocks sync.Map
+
+func lockJPD(id string) func() {
+	lock, _ := jpdLocks.LoadOrStore(id, &sync.Mutex{})
+	mutex := lock.(*sync.Mutex)
+	mutex.Lock()
+
+	return mutex.Unlock
+}
+
+// errJPDNotFound is returned by getJPD when Mission Control has no Platform
+// Deployment with the ID.
+var errJPDNotFound = errors.New("Platform Deployment not found")
+
+// getJPD fetches the Platform Deployment with the ID.
+func getJPD(client *resty.Client, id string) (*jpdGetResponseAPIModel, error) {
+	var jpd jpdGetResponseAPIModel
+	response, err := client.R().
+		SetPathParam("id", id).
+		SetResult(&jpd).
+		Get(jpdEndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode() == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", errJPDNotFound, response.String())
+	}
+
+	if response.IsError() {
+		return nil, fmt.Errorf("%s", response.String())
+	}
+
+	return &jpd, nil
+}
+
+// updateJPD reads the Platform Deployment and writes it back with the changes
+// made by update to the request, e.g. to its tags. Mission Control doesn't
+// return the join key so token, when set, is sent along instead.
+func updateJPD(client *resty.Client, id, token string, update func(jpd *jpdGetResponseAPIModel, request *jpdPostRequestAPIModel)) error {
+	unlock := lockJPD(id)
+	defer unlock()
+
+	jpd, err := getJPD(client, id)
+	if err != nil {
+		return fmt.Errorf("unable to read Platform Deployment %s: %w", id, err)
+	}
+
+	request := jpdPostRequestAPIModel{
+		Name:     jpd.Name,
+		URL:      jpd.URL,
+		Token:    token,
+		Location: jpd.Location,
+		Tags:     jpd.Tags,
+	}
+	update(jpd, &request)
+
+	response, err := client.R().
+		SetPathParam("id", id).
+		SetBody(request).
+		Put(jpdEndpoint)
+
+	if err != nil {
+		return err
+	}
+
+	if response.IsError() {
+		return fmt.Errorf("%s", response.String())
+	}
+
+	return nil
+}
+
+// resolveJPDNames looks up the ID of each Platform Deployment by name. Every
+// name must match exactly one Platform Deployment.
+func resolveJPDNames(client *resty.Client, names []string) (map[string]string, error) {
+	var jpds []jpdGetResponseAPIModel
+	response, err := client.R().
+		SetResult(&jpds).
+		Get(jpdsEndpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if response.IsError() {
+		return nil, fmt.Errorf("%s", response.String())
+	}
+
+	ids := map[string]string{}
+	for _, name := range lo.Uniq(names) {
+		matches := lo.Filter(jpds, func(jpd jpdGetResponseAPIModel, _ int) bool {
+			return jpd.Name == name
+		})
+
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("no Platform Deployment is named %s", name)
+		case 1:
+			ids[name] = matches[0].ID
+		default:
+			return nil, fmt.Errorf("%d Platform Deployments are named %s, use the ID instead", len(matches), name)
+		}
+	}
+
+	return ids, nil
+}
+
 type jpdLicenseAPIModel struct {
 	Expired      bool   `json:"expired"`
 	LicenseHash  string `json:"license_hash"`
@@ -451,10 +697,79 @@ func (r *jpdResource) Configure(ctx context.Context, req resource.ConfigureReque
 	if req.ProviderData == nil {
 		return
 	}
-	r.ProviderData = req.ProviderData.(util.ProviderMetadata)
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *jpdResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, r.TypeName, req, resp)...)
+
+	// nothing to check when the resource is being destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan jpdResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.Token.IsNull() {
+		resp.Diagnostics.Append(r.ProviderData.capabilities.Require(jpdJoinKeyCapability, path.Root("token"))...)
+	}
+
+	if !plan.Decommission.IsNull() && !plan.Decommission.IsUnknown() {
+		if detachLicenses, ok := plan.Decommission.Attributes()["detach_licenses"].(types.Bool); ok && detachLicenses.ValueBool() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("decommission").AtName("detach_licenses"),
+				"Detaching licenses is not supported",
+				"Mission Control has no documented API to detach the licenses of a Platform Deployment back to their license buckets. Detach the licenses in Mission Control before destroying the Platform Deployment, and set `detach_licenses` to `false`.",
+			)
+		}
+	}
+
+	if r.ProviderData.defaultTagsUnknown {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.SetUnknown(types.StringType))...)
+	} else if !plan.Tags.IsUnknown() {
+		var tags []string
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+		tags = lo.Union(tags, r.ProviderData.defaultTags)
+
+		// external tags are kept on update
+		if plan.IgnoreExternalTags.ValueBool() && !req.State.Raw.IsNull() {
+			var state jpdResourceModel
+			resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			var stateTagsAll []string
+			resp.Diagnostics.Append(state.TagsAll.ElementsAs(ctx, &stateTagsAll, false)...)
+
+			defaultTags, d := appliedDefaultTags(ctx, req.Private, r.ProviderData.defaultTags)
+			resp.Diagnostics.Append(d...)
+
+			externalTags, d := state.externalTags(ctx, stateTagsAll, defaultTags)
+			resp.Diagnostics.Append(d...)
+			tags = lo.Union(tags, externalTags)
+		}
+
+		tagsAll, d := types.SetValueFrom(ctx, types.StringType, tags)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+	}
 }
 
 func (r *jpdResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan jpdResourceModel
@@ -465,7 +780,7 @@ func (r *jpdResource) Create(ctx context.Context, req resource.CreateRequest, re
 	}
 
 	var jpd jpdPostRequestAPIModel
-	resp.Diagnostics.Append(plan.toAPIModel(ctx, &jpd, r.ProviderData.ArtifactoryVersion)...)
+	resp.Diagnostics.Append(plan.toAPIModel(ctx, &jpd, r.ProviderData.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -488,11 +803,12 @@ func (r *jpdResource) Create(ctx context.Context, req resource.CreateRequest, re
 
 	// Convert from the API data model to the Terraform data model
 	// and refresh any attribute values.
-	resp.Diagnostics.Append(plan.fromAPIModel(ctx, &result)...)
+	resp.Diagnostics.Append(plan.fromAPIModel(ctx, &result, r.ProviderData.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(setAppliedDefaultTags(ctx, resp.Private, r.ProviderData.defaultTags)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
@@ -522,9 +838,20 @@ func (r *jpdResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
+	// imported resource has no ignore external tags in state yet
+	if state.IgnoreExternalTags.IsNull() {
+		state.IgnoreExternalTags = types.BoolValue(false)
+	}
+
+	// imported resource has no deletion protection in state yet, and is not
+	// protected until the default is applied
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
 	// Convert from the API data model to the Terraform data model
 	// and refresh any attribute values.
-	resp.Diagnostics.Append(state.fromAPIModel(ctx, &jpd)...)
+	resp.Diagnostics.Append(state.fromAPIModel(ctx, &jpd, r.ProviderData.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -533,6 +860,11 @@ func (r *jpdResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 }
 
 func (r *jpdResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan jpdResourceModel
@@ -550,11 +882,35 @@ func (r *jpdResource) Update(ctx context.Context, req resource.UpdateRequest, re
 	}
 
 	var jpd jpdPostRequestAPIModel
-	resp.Diagnostics.Append(plan.toAPIModel(ctx, &jpd, r.ProviderData.ArtifactoryVersion)...)
+	resp.Diagnostics.Append(plan.toAPIModel(ctx, &jpd, r.ProviderData.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	unlock := lockJPD(state.ID.ValueString())
+	defer unlock()
+
+	if plan.IgnoreExternalTags.ValueBool() {
+		current, err := getJPD(r.ProviderData.Client, state.ID.ValueString())
+		if err != nil {
+			utilfw.UnableToUpdateResourceError(resp, err.Error())
+			return
+		}
+
+		defaultTags, d := appliedDefaultTags(ctx, req.Private, r.ProviderData.defaultTags)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		externalTags, d := state.externalTags(ctx, current.Tags, defaultTags)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		jpd.Tags = lo.Union(jpd.Tags, externalTags)
+	}
+
 	response, err := r.ProviderData.Client.R().
 		SetPathParam("id", state.ID.ValueString()).
 		SetBody(jpd).
@@ -588,15 +944,21 @@ func (r *jpdResource) Update(ctx context.Context, req resource.UpdateRequest, re
 
 	// Convert from the API data model to the Terraform data model
 	// and refresh any attribute values.
-	resp.Diagnostics.Append(plan.fromAPIModel(ctx, &result)...)
+	resp.Diagnostics.Append(plan.fromAPIModel(ctx, &result, r.ProviderData.defaultTags)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(setAppliedDefaultTags(ctx, resp.Private, r.ProviderData.defaultTags)...)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
 func (r *jpdResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var state jpdResourceModel
@@ -607,6 +969,24 @@ func (r *jpdResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
+	if deletionProtected(state.DeletionProtection) {
+		utilfw.UnableToDeleteResourceError(resp, "`deletion_protection` is enabled. Set `deletion_protection` to `false` and apply, before the resource can be destroyed.")
+		return
+	}
+
+	if !state.Decommission.IsNull() {
+		var decommission jpdDecommissionResourceModel
+		resp.Diagnostics.Append(state.Decommission.As(ctx, &decommission, basetypes.ObjectAsOptions{})...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(decommissionJPD(r.ProviderData.Client, state.ID.ValueString(), decommission)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	response, err := r.ProviderData.Client.R().
 		SetPathParam("id", state.ID.ValueString()).
 		Delete(jpdEndpoint)
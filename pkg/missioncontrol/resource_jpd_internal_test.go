@@ -0,0 +1,98 @@
+package missioncontrol
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testPrivateState stores private state keys in memory.
+type testPrivateState map[string][]byte
+
+func (p testPrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return p[key], nil
+}
+
+func (p testPrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	p[key] = value
+	return nil
+}
+
+func TestExternalTags_removedDefaultTag(t *testing.T) {
+	ctx := context.Background()
+
+	private := testPrivateState{}
+	if ds := setAppliedDefaultTags(ctx, private, []string{"env:prod", "owner:platform"}); ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	// `owner:platform` has since been removed from the provider default tags
+	defaultTags, ds := appliedDefaultTags(ctx, private, []string{"env:prod"})
+	if ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	state := jpdResourceModel{
+		Tags: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("prod")}),
+	}
+
+	externalTags, ds := state.externalTags(ctx, []string{"prod", "env:prod", "owner:platform", "team:payments"}, defaultTags)
+	if ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	if expected := []string{"team:payments"}; !reflect.DeepEqual(externalTags, expected) {
+		t.Errorf("expected external tags %v, got %v", expected, externalTags)
+	}
+}
+
+func TestAppliedDefaultTags_notRecorded(t *testing.T) {
+	defaultTags, ds := appliedDefaultTags(context.Background(), testPrivateState{}, []string{"env:prod"})
+	if ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	if expected := []string{"env:prod"}; !reflect.DeepEqual(defaultTags, expected) {
+		t.Errorf("expected default tags %v, got %v", expected, defaultTags)
+	}
+}
+
+func TestJPDToAPIModel_joinKey(t *testing.T) {
+	model := jpdResourceModel{
+		Name:     types.StringValue("my-jpd"),
+		URL:      types.StringValue("https://my-jpd/"),
+		Token:    types.StringValue("join-key"),
+		Username: types.StringNull(),
+		Password: types.StringNull(),
+		Tags:     types.SetNull(types.StringType),
+		Location: types.ObjectValueMust(
+			map[string]attr.Type{
+				"city_name":    types.StringType,
+				"country_code": types.StringType,
+				"latitude":     types.Float64Type,
+				"longitude":    types.Float64Type,
+			},
+			map[string]attr.Value{
+				"city_name":    types.StringValue("San Francisco"),
+				"country_code": types.StringValue("US"),
+				"latitude":     types.Float64Value(37.7749),
+				"longitude":    types.Float64Value(122.4194),
+			},
+		),
+	}
+
+	// the join key is sent whatever the platform version, which toAPIModel
+	// doesn't depend on
+	var jpd jpdPostRequestAPIModel
+	if ds := model.toAPIModel(context.Background(), &jpd, nil); ds.HasError() {
+		t.Fatalf("unexpected error: %v", ds)
+	}
+
+	if jpd.Token != "join-key" || jpd.Username != "" || jpd.Password != "" {
+		t.Errorf("expected join key only, got token %q, username %q, password %q", jpd.Token, jpd.Username, jpd.Password)
+	}
+}
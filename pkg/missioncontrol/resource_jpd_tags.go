@@ -0,0 +1,291 @@
+package missioncontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jfrog/terraform-provider-shared/util"
+	utilfw "github.com/jfrog/terraform-provider-shared/util/fw"
+	"github.com/samber/lo"
+)
+
+var _ resource.Resource = &jpdTagsResource{}
+var _ resource.ResourceWithImportState = &jpdTagsResource{}
+
+type jpdTagsResource struct {
+	ProviderData ProviderMetadata
+	TypeName     string
+}
+
+func NewJPDTagsResource() resource.Resource {
+	return &jpdTagsResource{
+		TypeName: "missioncontrol_jpd_tags",
+	}
+}
+
+func (r *jpdTagsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = r.TypeName
+}
+
+func (r *jpdTagsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the Platform Deployment and the sorted tags of this resource, in the form of `jpd_id:tag[,tag...]`, so resources tagging the same Platform Deployment have different IDs.",
+			},
+			"jpd_id": schema.StringAttribute{
+				Required: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the Platform Deployment to tag.",
+			},
+			"token": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				MarkdownDescription: "JPD join key, sent along with the tags. Mission Control never returns the join key, so tags are otherwise updated without it, like a `missioncontrol_jpd` resource without `token`. Set it when Mission Control rejects the update for the missing join key.",
+			},
+			"tags": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthAtLeast(1),
+					),
+				},
+				Description: "Tags to add to the Platform Deployment. Other tags of the Platform Deployment are left as is.",
+			},
+		},
+		MarkdownDescription: "Provides a resource to add tags to an existing [JFrog Platform Deployment](https://jfrog.com/help/r/jfrog-platform-administration-documentation/manage-platform-deployments), without managing the Platform Deployment itself. Only the tags of this resource are added and removed, so several `missioncontrol_jpd_tags` resources can tag the same Platform Deployment.\n\n" +
+			"~>When the Platform Deployment is also managed with `missioncontrol_jpd` resource, set its `ignore_external_tags` attribute to `true`. Otherwise the tags of this resource are removed on every apply of `missioncontrol_jpd`.",
+	}
+}
+
+type jpdTagsResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	JPDID types.String `tfsdk:"jpd_id"`
+	Token types.String `tfsdk:"token"`
+	Tags  types.Set    `tfsdk:"tags"`
+}
+
+// jpdTagsID returns the resource ID for the tags of the Platform Deployment,
+// in the same form as the import identifier.
+func jpdTagsID(jpdID string, tags []string) string {
+	tags = lo.Uniq(tags)
+	slices.Sort(tags)
+
+	return jpdID + ":" + strings.Join(tags, ",")
+}
+
+// updateJPDTags replaces the tags of the Platform Deployment with the result of
+// update, which is given the current tags. Mission Control doesn't return the
+// join key so token, when set, is sent along instead.
+func updateJPDTags(client *resty.Client, id, token string, update func(tags []string) []string) error {
+	return updateJPD(client, id, token, func(jpd *jpdGetResponseAPIModel, request *jpdPostRequestAPIModel) {
+		request.Tags = update(jpd.Tags)
+	})
+}
+
+func (r *jpdTagsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *jpdTagsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan jpdTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateJPDTags(r.ProviderData.Client, plan.JPDID.ValueString(), plan.Token.ValueString(), func(current []string) []string {
+		return lo.Union(current, tags)
+	})
+	if err != nil {
+		utilfw.UnableToCreateResourceError(resp, err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(jpdTagsID(plan.JPDID.ValueString(), tags))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *jpdTagsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	go util.SendUsageResourceRead(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state jpdTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jpd, err := getJPD(r.ProviderData.Client, state.JPDID.ValueString())
+	if errors.Is(err, errJPDNotFound) {
+		resp.Diagnostics.AddWarning(
+			"Platform Deployment not found",
+			fmt.Sprintf("Platform Deployment %s no longer exists. Removing from Terraform state.", state.JPDID.ValueString()),
+		)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		utilfw.UnableToRefreshResourceError(resp, err.Error())
+		return
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// only tags of this resource which were removed outside of Terraform are
+	// drift, other tags of the JPD are not managed
+	tagsSet, d := types.SetValueFrom(ctx, types.StringType, lo.Intersect(tags, jpd.Tags))
+	resp.Diagnostics.Append(d...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Tags = tagsSet
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *jpdTagsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceUpdate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var plan jpdTagsResourceModel
+	var state jpdTagsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planTags, stateTags []string
+	resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &planTags, false)...)
+	resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &stateTags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removedTags, _ := lo.Difference(stateTags, planTags)
+
+	err := updateJPDTags(r.ProviderData.Client, plan.JPDID.ValueString(), plan.Token.ValueString(), func(current []string) []string {
+		return lo.Union(lo.Without(current, removedTags...), planTags)
+	})
+	if err != nil {
+		utilfw.UnableToUpdateResourceError(resp, err.Error())
+		return
+	}
+
+	plan.ID = types.StringValue(jpdTagsID(plan.JPDID.ValueString(), planTags))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *jpdTagsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
+
+	var state jpdTagsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tags []string
+	resp.Diagnostics.Append(state.Tags.ElementsAs(ctx, &tags, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateJPDTags(r.ProviderData.Client, state.JPDID.ValueString(), state.Token.ValueString(), func(current []string) []string {
+		return lo.Without(current, tags...)
+	})
+	if err != nil {
+		utilfw.UnableToDeleteResourceError(resp, err.Error())
+		return
+	}
+
+	// If the logic reaches here, it implicitly succeeded and will remove
+	// the resource from state if there are no other errors.
+}
+
+// ImportState imports the resource into the Terraform state. The import
+// identifier holds the tags this resource manages, as other tags of the
+// Platform Deployment can't be told apart.
+func (r *jpdTagsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// tags may contain ':' themselves, e.g. `team:payments`
+	jpdID, tags, found := strings.Cut(req.ID, ":")
+	if !found || jpdID == "" || tags == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			"Expected import identifier in the form of: jpd_id:tag[,tag...]",
+		)
+		return
+	}
+
+	tagsList := lo.Uniq(strings.Split(tags, ","))
+	tagsSet, ds := types.SetValueFrom(ctx, types.StringType, tagsList)
+	resp.Diagnostics.Append(ds...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), jpdTagsID(jpdID, tagsList))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("jpd_id"), jpdID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tags"), tagsSet)...)
+}
@@ -0,0 +1,15 @@
+package missioncontrol
+
+import "testing"
+
+func TestJPDTagsID(t *testing.T) {
+	// resources tagging the same JPD have different IDs, whatever the order
+	// of their tags
+	if id := jpdTagsID("JPD-1", []string{"team:search", "team:payments", "team:search"}); id != "JPD-1:team:payments,team:search" {
+		t.Errorf("expected ID JPD-1:team:payments,team:search, got %s", id)
+	}
+
+	if jpdTagsID("JPD-1", []string{"team:payments"}) == jpdTagsID("JPD-1", []string{"team:search"}) {
+		t.Error("expected different IDs for different tags of the same JPD")
+	}
+}
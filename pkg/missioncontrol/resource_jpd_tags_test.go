@@ -0,0 +1,110 @@
+package missioncontrol_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jfrog/terraform-provider-shared/testutil"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// To make tests work runs ./scripts/run-artifactory-2.sh which will export env var `ARTIFACTORY_URL_2`
+func TestAccJpdTags_full(t *testing.T) {
+	var skipTest = func() (bool, string) {
+		if len(os.Getenv("ARTIFACTORY_URL_2")) > 0 && len(os.Getenv("ARTIFACTORY_JOIN_KEY")) > 0 {
+			return false, "Env var `ARTIFACTORY_URL_2` and `ARTIFACTORY_JOIN_KEY` are set. Executing test."
+		}
+
+		return true, "Env var `ARTIFACTORY_URL_2` or `ARTIFACTORY_JOIN_KEY` are not set. Skipping test."
+	}
+
+	if skip, reason := skipTest(); skip {
+		t.Skip(reason)
+	}
+
+	_, jpdFqrn, jpdName := testutil.MkNames("test-jpd", "missioncontrol_jpd")
+	_, fqrn, resourceName := testutil.MkNames("test-jpd-tags", "missioncontrol_jpd_tags")
+
+	temp := `
+	resource "missioncontrol_jpd" "{{ .jpdName }}" {
+		name = "{{ .jpdName }}"
+		url  = "http://host.docker.internal:9082/"
+		token  = "{{ .token }}"
+		deletion_protection = false
+
+		location = {
+			city_name = "San Francisco"
+			country_code = "US"
+			latitude = 37.7749
+			longitude = 122.4194
+		}
+
+		tags = ["prod"]
+		ignore_external_tags = true
+	}
+
+	resource "missioncontrol_jpd_tags" "{{ .name }}" {
+		jpd_id = missioncontrol_jpd.{{ .jpdName }}.id
+		tags   = {{ .tags }}
+	}`
+
+	testData := map[string]string{
+		"jpdName": jpdName,
+		"name":    resourceName,
+		"token":   os.Getenv("ARTIFACTORY_JOIN_KEY"),
+		"tags":    `["team:payments"]`,
+	}
+
+	config := util.ExecuteTemplate(resourceName, temp, testData)
+
+	testData["tags"] = `["team:payments", "team:search"]`
+	updatedConfig := util.ExecuteTemplate(resourceName, temp, testData)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(fqrn, "jpd_id", jpdFqrn, "id"),
+					resource.TestCheckResourceAttrWith(fqrn, "id", func(value string) error {
+						if !strings.HasSuffix(value, ":team:payments") {
+							return fmt.Errorf("expected ID to end with :team:payments, got %s", value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttr(fqrn, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags.*", "team:payments"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags.*", "team:payments"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags.*", "team:search"),
+					resource.TestCheckResourceAttr(jpdFqrn, "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr(jpdFqrn, "tags.*", "prod"),
+					resource.TestCheckResourceAttr(jpdFqrn, "tags_all.#", "3"),
+				),
+			},
+			{
+				Config:   updatedConfig,
+				PlanOnly: true,
+			},
+			{
+				ResourceName: fqrn,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources[jpdFqrn].Primary.ID + ":team:payments,team:search", nil
+				},
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
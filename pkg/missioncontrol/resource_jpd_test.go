@@ -20,7 +20,7 @@ func TestAccJpd_full(t *testing.T) {
 	}
 
 	if skip, reason := skipTest(); skip {
-		t.Skipf(reason)
+		t.Skip(reason)
 	}
 
 	_, fqrn, resourceName := testutil.MkNames("test-jpd", "missioncontrol_jpd")
@@ -30,6 +30,7 @@ func TestAccJpd_full(t *testing.T) {
 		name = "{{ .name }}"
 		url  = "http://host.docker.internal:9082/"
 		token  = "{{ .token }}"
+		deletion_protection = false
 
 		location = {
 			city_name = "San Francisco"
@@ -58,6 +59,7 @@ func TestAccJpd_full(t *testing.T) {
 		name = "{{ .name }}"
 		url  = "http://host.docker.internal:9082/"
 		token  = "{{ .token }}"
+		deletion_protection = false
 
 		location = {
 			city_name = "New York"
@@ -69,6 +71,8 @@ func TestAccJpd_full(t *testing.T) {
 		tags = [
 			"dev",
 		]
+
+		decommission = {}
 	}`
 	updatedConfig := util.ExecuteTemplate(resourceName, updatedTemp, testData)
 
@@ -130,13 +134,85 @@ func TestAccJpd_full(t *testing.T) {
 					resource.TestCheckResourceAttrSet(fqrn, "licenses.0.valid_through"),
 					resource.TestCheckResourceAttr(fqrn, "is_cold_storage", "false"),
 					resource.TestCheckNoResourceAttr(fqrn, "cold_storage_jpd"),
+					resource.TestCheckResourceAttr(fqrn, "decommission.detach_licenses", "false"),
+					resource.TestCheckResourceAttr(fqrn, "decommission.remove_from_federations", "true"),
 				),
 			},
 			{
 				ResourceName:            fqrn,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"token", "username", "password"},
+				ImportStateVerifyIgnore: []string{"token", "username", "password", "decommission", "deletion_protection"},
+			},
+		},
+	})
+}
+
+func TestAccJpd_default_tags(t *testing.T) {
+	var skipTest = func() (bool, string) {
+		if len(os.Getenv("ARTIFACTORY_URL_2")) > 0 && len(os.Getenv("ARTIFACTORY_JOIN_KEY")) > 0 {
+			return false, "Env var `ARTIFACTORY_URL_2` and `ARTIFACTORY_JOIN_KEY` are set. Executing test."
+		}
+
+		return true, "Env var `ARTIFACTORY_URL_2` or `ARTIFACTORY_JOIN_KEY` are not set. Skipping test."
+	}
+
+	if skip, reason := skipTest(); skip {
+		t.Skip(reason)
+	}
+
+	_, fqrn, resourceName := testutil.MkNames("test-jpd", "missioncontrol_jpd")
+
+	temp := `
+	provider "missioncontrol" {
+		default_tags = ["owner:platform", "dev"]
+	}
+
+	resource "missioncontrol_jpd" "{{ .name }}" {
+		name = "{{ .name }}"
+		url  = "http://host.docker.internal:9082/"
+		token  = "{{ .token }}"
+		deletion_protection = false
+
+		location = {
+			city_name = "San Francisco"
+			country_code = "US"
+			latitude = 37.7749
+			longitude = 122.4194
+		}
+
+		tags = [
+			"prod",
+			"dev",
+		]
+	}`
+
+	testData := map[string]string{
+		"name":  resourceName,
+		"token": os.Getenv("ARTIFACTORY_JOIN_KEY"),
+	}
+
+	config := util.ExecuteTemplate(resourceName, temp, testData)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProviders(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "tags.#", "2"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags.*", "prod"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags.*", "dev"),
+					resource.TestCheckResourceAttr(fqrn, "tags_all.#", "3"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags_all.*", "prod"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags_all.*", "dev"),
+					resource.TestCheckTypeSetElemAttr(fqrn, "tags_all.*", "owner:platform"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
 			},
 		},
 	})
@@ -29,9 +29,10 @@ const (
 )
 
 var _ resource.Resource = &licenseBucketResource{}
+var _ resource.ResourceWithModifyPlan = &licenseBucketResource{}
 
 type licenseBucketResource struct {
-	ProviderData util.ProviderMetadata
+	ProviderData ProviderMetadata
 	TypeName     string
 }
 
@@ -127,26 +128,28 @@ func (r *licenseBucketResource) Schema(ctx context.Context, req resource.SchemaR
 				Computed:    true,
 				Description: "The number of used licenses in this bucket.",
 			},
+			"deletion_protection": deletionProtectionSchemaAttribute,
 		},
 		MarkdownDescription: "Provides a JFrog [license bucket](https://jfrog.com/help/r/jfrog-platform-administration-documentation/manage-license-buckets) resource to manage license buckets.",
 	}
 }
 
 type licenseBucketResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	URL         types.String `tfsdk:"url"`
-	File        types.String `tfsdk:"file"`
-	Key         types.String `tfsdk:"key"`
-	Subject     types.String `tfsdk:"subject"`
-	ProductName types.String `tfsdk:"product_name"`
-	ProductID   types.Int64  `tfsdk:"product_id"`
-	LicenseType types.String `tfsdk:"license_type"`
-	IssuedDate  types.String `tfsdk:"issued_date"`
-	ValidDate   types.String `tfsdk:"valid_date"`
-	Signature   types.String `tfsdk:"signature"`
-	Quantity    types.Int64  `tfsdk:"quantity"`
-	Used        types.Int64  `tfsdk:"used"`
+	ID                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	URL                types.String `tfsdk:"url"`
+	File               types.String `tfsdk:"file"`
+	Key                types.String `tfsdk:"key"`
+	Subject            types.String `tfsdk:"subject"`
+	ProductName        types.String `tfsdk:"product_name"`
+	ProductID          types.Int64  `tfsdk:"product_id"`
+	LicenseType        types.String `tfsdk:"license_type"`
+	IssuedDate         types.String `tfsdk:"issued_date"`
+	ValidDate          types.String `tfsdk:"valid_date"`
+	Signature          types.String `tfsdk:"signature"`
+	Quantity           types.Int64  `tfsdk:"quantity"`
+	Used               types.Int64  `tfsdk:"used"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *licenseBucketResourceModel) fromAPIModel(_ context.Context, apiModel *licenseBucketPostResponseAPIModel) (ds diag.Diagnostics) {
@@ -198,10 +201,19 @@ func (r *licenseBucketResource) Configure(ctx context.Context, req resource.Conf
 	if req.ProviderData == nil {
 		return
 	}
-	r.ProviderData = req.ProviderData.(util.ProviderMetadata)
+	r.ProviderData = req.ProviderData.(ProviderMetadata)
+}
+
+func (r *licenseBucketResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkDeletionProtection(ctx, r.TypeName, req, resp)...)
 }
 
 func (r *licenseBucketResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "create")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceCreate(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var plan licenseBucketResourceModel
@@ -315,14 +327,43 @@ func (r *licenseBucketResource) Read(ctx context.Context, req resource.ReadReque
 	state.Quantity = types.Int64Value(matchedBucket.Size)
 	state.LicenseType = types.StringValue(matchedBucket.Type)
 
+	// state from before deletion protection has no value yet, and is not
+	// protected until the default is applied
+	if state.DeletionProtection.IsNull() {
+		state.DeletionProtection = types.BoolValue(false)
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *licenseBucketResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// noop
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "update")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan licenseBucketResourceModel
+	var state licenseBucketResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// every other attribute requires replacement, so only deletion protection
+	// can change
+	state.DeletionProtection = plan.DeletionProtection
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
 }
 
 func (r *licenseBucketResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(r.ProviderData.checkReadOnly(r.TypeName, "delete")...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	go util.SendUsageResourceDelete(ctx, r.ProviderData.Client.R(), r.ProviderData.ProductId, r.TypeName)
 
 	var state licenseBucketResourceModel
@@ -333,6 +374,11 @@ func (r *licenseBucketResource) Delete(ctx context.Context, req resource.DeleteR
 		return
 	}
 
+	if deletionProtected(state.DeletionProtection) {
+		utilfw.UnableToDeleteResourceError(resp, "`deletion_protection` is enabled. Set `deletion_protection` to `false` and apply, before the resource can be destroyed.")
+		return
+	}
+
 	response, err := r.ProviderData.Client.R().
 		SetPathParam("name", state.Name.ValueString()).
 		Delete(licenseBucketEndpoint)
@@ -2,6 +2,7 @@ package missioncontrol_test
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -31,21 +32,27 @@ func TestAccLicenseBucket_url(t *testing.T) {
 		name = "{{ .name }}"
 		url  = "{{ .url }}"
 		key  = "{{ .key }}"
+		deletion_protection = {{ .deletionProtection }}
 	}`
 
 	testData := map[string]string{
-		"name": resourceName,
-		"url":  jfrogLicenseBucketURL,
-		"key":  jfrogLicenseBucketKey,
+		"name":               resourceName,
+		"url":                jfrogLicenseBucketURL,
+		"key":                jfrogLicenseBucketKey,
+		"deletionProtection": "true",
 	}
 
 	config := util.ExecuteTemplate(resourceName, temp, testData)
 
+	testData["deletionProtection"] = "false"
+	unprotectedConfig := util.ExecuteTemplate(resourceName, temp, testData)
+
 	updatedTemp := `
 	resource "missioncontrol_license_bucket" "{{ .name }}" {
 		name = "{{ .name }}-2"
 		url  = "{{ .url }}"
 		key  = "{{ .key }}"
+		deletion_protection = false
 	}`
 	updatedConfig := util.ExecuteTemplate(resourceName, updatedTemp, testData)
 
@@ -68,6 +75,18 @@ func TestAccLicenseBucket_url(t *testing.T) {
 					resource.TestCheckResourceAttr(fqrn, "license_type", "ENTERPRISE_PLUS_TRIAL"),
 					resource.TestCheckResourceAttr(fqrn, "quantity", "5"),
 					resource.TestCheckResourceAttr(fqrn, "used", "0"),
+					resource.TestCheckResourceAttr(fqrn, "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      updatedConfig,
+				ExpectError: regexp.MustCompile(".*Deletion protection is enabled.*"),
+			},
+			{
+				Config: unprotectedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(fqrn, "name", testData["name"]),
+					resource.TestCheckResourceAttr(fqrn, "deletion_protection", "false"),
 				),
 			},
 			{
@@ -103,6 +122,7 @@ func TestAccLicenseBucket_file(t *testing.T) {
 		name = "{{ .name }}"
 		file = "{{ .file }}"
 		key  = "{{ .key }}"
+		deletion_protection = false
 	}`
 
 	testData := map[string]string{
@@ -118,6 +138,7 @@ func TestAccLicenseBucket_file(t *testing.T) {
 		name = "{{ .name }}-2"
 		file = "{{ .file }}"
 		key  = "{{ .key }}"
+		deletion_protection = false
 	}`
 	updatedConfig := util.ExecuteTemplate(resourceName, updatedTemp, testData)
 
@@ -0,0 +1,247 @@
+package missioncontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/samber/lo"
+)
+
+// Ensure our implementation satisfies the validator.String interface.
+var _ validator.String = &permissionFilterPatternValidator{}
+
+// permissionFilterPatternValidator validates a permission filter pattern is a
+// valid Java regular expression, as used by Mission Control.
+type permissionFilterPatternValidator struct{}
+
+func (v permissionFilterPatternValidator) Description(_ context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v permissionFilterPatternValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v permissionFilterPatternValidator) ValidateString(ctx context.Context, request validator.StringRequest, response *validator.StringResponse) {
+	if request.ConfigValue.IsNull() || request.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := request.ConfigValue.ValueString()
+
+	// Java regular expression syntax is largely a superset of RE2 syntax
+	// (Perl flags). Constructs which only Java supports are left to Mission
+	// Control to verify.
+	_, err := syntax.Parse(value, syntax.Perl)
+	if err == nil {
+		return
+	}
+
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && isJavaOnlySyntax(syntaxErr) {
+		response.Diagnostics.AddAttributeWarning(
+			request.Path,
+			"Unable to validate regular expression",
+			fmt.Sprintf("Pattern %q uses syntax which can't be verified before apply: %s", value, err),
+		)
+		return
+	}
+
+	response.Diagnostics.Append(validatordiag.InvalidAttributeValueMatchDiagnostic(
+		request.Path,
+		fmt.Sprintf("%s: %s", v.Description(ctx), err),
+		value,
+	))
+}
+
+var (
+	javaBackreference    = regexp.MustCompile(`^\\([1-9]|k)$`)
+	javaCharacterClass   = regexp.MustCompile(`^\\[pP]\{java[A-Za-z]+\}$`)
+	javaPossessiveRepeat = regexp.MustCompile(`^([*+?]|\{[0-9,]+\})\+$`)
+)
+
+// isJavaOnlySyntax returns true for parse errors caused by constructs which
+// only Java supports: lookarounds, backreferences, possessive quantifiers, and
+// `\p{javaX}` character classes. Other parse errors, e.g. a mistyped escape
+// like `\q`, are invalid in Java too.
+func isJavaOnlySyntax(err *syntax.Error) bool {
+	switch err.Code {
+	case syntax.ErrInvalidPerlOp:
+		// lookaheads
+		return err.Expr == "(?=" || err.Expr == "(?!"
+	case syntax.ErrInvalidNamedCapture:
+		// lookbehinds
+		return strings.HasPrefix(err.Expr, "(?<=") || strings.HasPrefix(err.Expr, "(?<!")
+	case syntax.ErrInvalidEscape:
+		return javaBackreference.MatchString(err.Expr)
+	case syntax.ErrInvalidCharRange:
+		return javaCharacterClass.MatchString(err.Expr)
+	case syntax.ErrInvalidRepeatOp:
+		return javaPossessiveRepeat.MatchString(err.Expr)
+	}
+	return false
+}
+
+func isPermissionFilterPattern() validator.String {
+	return permissionFilterPatternValidator{}
+}
+
+// isEntityType only validates the format of an entity type. Whether the
+// platform can sync it is checked during plan.
+func isEntityType() validator.String {
+	return stringvalidator.RegexMatches(regexp.MustCompile(`^[A-Z][A-Z_]*$`), "must be an entity type in upper case, e.g. `USERS`")
+}
+
+// Ensure our implementation satisfies the resource.ConfigValidator interface.
+var _ resource.ConfigValidator = &permissionFiltersConfigValidator{}
+
+// permissionFiltersConfigValidator validates permission filters of Access
+// Federation targets against the synced entities. It supports the star
+// resource, with `targets` nested attribute, the topology resource, with
+// `edges` nested attribute, and the single target resource.
+type permissionFiltersConfigValidator struct{}
+
+func (v permissionFiltersConfigValidator) Description(_ context.Context) string {
+	return "permission filters require `PERMISSIONS` entity to be synced, and a pattern can't be both included and excluded"
+}
+
+func (v permissionFiltersConfigValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v permissionFiltersConfigValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	schemaAttributes := req.Config.Schema.GetAttributes()
+
+	entities := types.SetNull(types.StringType)
+	if _, ok := schemaAttributes["entities"]; ok {
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("entities"), &entities)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	nestedName, found := lo.Find([]string{"targets", "edges"}, func(name string) bool {
+		_, ok := schemaAttributes[name]
+		return ok
+	})
+
+	if !found {
+		var permissionFilters types.Object
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("permission_filters"), &permissionFilters)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(validatePermissionFilters(ctx, entities, permissionFilters, path.Root("permission_filters"))...)
+		return
+	}
+
+	var targets types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(nestedName), &targets)...)
+	if resp.Diagnostics.HasError() || targets.IsNull() || targets.IsUnknown() {
+		return
+	}
+
+	for _, target := range targets.Elements() {
+		targetObject, ok := target.(types.Object)
+		if !ok || targetObject.IsNull() || targetObject.IsUnknown() {
+			continue
+		}
+		attrs := targetObject.Attributes()
+
+		// target entities override the top level entities
+		targetEntities := entities
+		if e, ok := attrs["entities"].(types.Set); ok && !e.IsNull() {
+			targetEntities = e
+		}
+
+		permissionFilters, ok := attrs["permission_filters"].(types.Object)
+		if !ok {
+			continue
+		}
+
+		resp.Diagnostics.Append(validatePermissionFilters(
+			ctx,
+			targetEntities,
+			permissionFilters,
+			path.Root(nestedName).AtSetValue(target).AtName("permission_filters"),
+		)...)
+	}
+}
+
+func validatePermissionFilters(ctx context.Context, entities types.Set, permissionFilters types.Object, attrPath path.Path) (ds diag.Diagnostics) {
+	if permissionFilters.IsNull() || permissionFilters.IsUnknown() {
+		return
+	}
+
+	attrs := permissionFilters.Attributes()
+	includePatterns, includeKnown := knownStrings(attrs["include_patterns"])
+	excludePatterns, excludeKnown := knownStrings(attrs["exclude_patterns"])
+
+	if includeKnown && excludeKnown {
+		for _, pattern := range lo.Intersect(includePatterns, excludePatterns) {
+			ds.AddAttributeError(
+				attrPath,
+				"Conflicting permission filter patterns",
+				fmt.Sprintf("Pattern %q can't be in both `include_patterns` and `exclude_patterns`.", pattern),
+			)
+		}
+	}
+
+	if len(includePatterns) == 0 && len(excludePatterns) == 0 {
+		return
+	}
+
+	if entities.IsNull() || entities.IsUnknown() {
+		return
+	}
+
+	var entityTypes []string
+	ds.Append(entities.ElementsAs(ctx, &entityTypes, false)...)
+
+	if !lo.Contains(entityTypes, "PERMISSIONS") {
+		ds.AddAttributeError(
+			attrPath,
+			"Permission filters have no effect",
+			"Permission filters only apply when `PERMISSIONS` is among the synced entities.",
+		)
+	}
+
+	return
+}
+
+// knownStrings returns the elements of a string set, and whether all of them
+// are known.
+func knownStrings(value attr.Value) ([]string, bool) {
+	set, ok := value.(types.Set)
+	if !ok || set.IsNull() {
+		return nil, true
+	}
+
+	if set.IsUnknown() {
+		return nil, false
+	}
+
+	var values []string
+	for _, elem := range set.Elements() {
+		s, ok := elem.(types.String)
+		if !ok || s.IsUnknown() {
+			return nil, false
+		}
+		values = append(values, s.ValueString())
+	}
+
+	return values, true
+}
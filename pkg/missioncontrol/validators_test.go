@@ -0,0 +1,53 @@
+package missioncontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPermissionFilterPatternValidator(t *testing.T) {
+	testCases := []struct {
+		pattern          string
+		expectedErrors   int
+		expectedWarnings int
+	}{
+		{pattern: "^team-.*$"},
+		{pattern: `a(?=b)`, expectedWarnings: 1},
+		{pattern: `a(?!b)`, expectedWarnings: 1},
+		{pattern: `(?<=a)b`, expectedWarnings: 1},
+		{pattern: `(?<!a)b`, expectedWarnings: 1},
+		{pattern: `(a)\1`, expectedWarnings: 1},
+		{pattern: `(?<name>a)\k<name>`, expectedWarnings: 1},
+		{pattern: `\p{javaLowerCase}+`, expectedWarnings: 1},
+		{pattern: `a++`, expectedWarnings: 1},
+		{pattern: `a{2}+`, expectedWarnings: 1},
+		{pattern: `\q`, expectedErrors: 1},
+		{pattern: `\p{Foo}`, expectedErrors: 1},
+		{pattern: `a**`, expectedErrors: 1},
+		{pattern: `(?>a)`, expectedErrors: 1},
+		{pattern: `[a`, expectedErrors: 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.pattern, func(t *testing.T) {
+			response := validator.StringResponse{Diagnostics: diag.Diagnostics{}}
+			isPermissionFilterPattern().ValidateString(
+				context.Background(),
+				validator.StringRequest{Path: path.Root("include_patterns"), ConfigValue: types.StringValue(testCase.pattern)},
+				&response,
+			)
+
+			if response.Diagnostics.ErrorsCount() != testCase.expectedErrors {
+				t.Errorf("expected %d errors, got %v", testCase.expectedErrors, response.Diagnostics.Errors())
+			}
+			if response.Diagnostics.WarningsCount() != testCase.expectedWarnings {
+				t.Errorf("expected %d warnings, got %v", testCase.expectedWarnings, response.Diagnostics.Warnings())
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package missioncontrol
+
+import (
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/jfrog/terraform-provider-shared/util"
+)
+
+// versionResolver detects the Artifactory version on first use and caches it
+// for the lifetime of the provider. A failed detection is not cached so a
+// later call may succeed.
+type versionResolver struct {
+	client *resty.Client
+
+	mutex              sync.Mutex
+	artifactoryVersion string
+}
+
+func newVersionResolver(client *resty.Client) *versionResolver {
+	return &versionResolver{
+		client: client,
+	}
+}
+
+// ArtifactoryVersion returns the Artifactory version, which capabilities are
+// checked against.
+func (v *versionResolver) ArtifactoryVersion() (string, error) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	if v.artifactoryVersion != "" {
+		return v.artifactoryVersion, nil
+	}
+
+	version, err := util.GetArtifactoryVersion(v.client)
+	if err != nil {
+		return "", err
+	}
+
+	v.artifactoryVersion = version
+
+	return v.artifactoryVersion, nil
+}
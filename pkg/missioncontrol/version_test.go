@@ -0,0 +1,76 @@
+package missioncontrol
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// newVersionServer returns a server reporting the Artifactory version, and
+// counting the requests. The Artifactory version endpoint is forbidden when
+// artifactoryVersion is empty.
+func newVersionServer(t *testing.T, artifactoryVersion string, artifactoryRequests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/artifactory/api/system/version" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		artifactoryRequests.Add(1)
+		if artifactoryVersion == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "` + artifactoryVersion + `"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVersionResolver_lazyAndCached(t *testing.T) {
+	var artifactoryRequests atomic.Int32
+	server := newVersionServer(t, "7.90.14", &artifactoryRequests)
+
+	versions := newVersionResolver(resty.New().SetBaseURL(server.URL))
+	if artifactoryRequests.Load() != 0 {
+		t.Fatalf("expected no request before first use, got %d", artifactoryRequests.Load())
+	}
+
+	for range 3 {
+		version, err := versions.ArtifactoryVersion()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if version != "7.90.14" {
+			t.Errorf("expected Artifactory version 7.90.14, got %s", version)
+		}
+	}
+
+	if artifactoryRequests.Load() != 1 {
+		t.Errorf("expected 1 Artifactory version request, got %d", artifactoryRequests.Load())
+	}
+}
+
+func TestVersionResolver_failureNotCached(t *testing.T) {
+	var artifactoryRequests atomic.Int32
+	server := newVersionServer(t, "", &artifactoryRequests)
+
+	versions := newVersionResolver(resty.New().SetBaseURL(server.URL))
+
+	for range 2 {
+		if _, err := versions.ArtifactoryVersion(); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+
+	if artifactoryRequests.Load() != 2 {
+		t.Errorf("expected 2 Artifactory version requests, got %d", artifactoryRequests.Load())
+	}
+}